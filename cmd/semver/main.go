@@ -0,0 +1,454 @@
+// Command semver validates, compares, sorts, filters, and increments semantic versions.
+//
+// Usage:
+//
+//	semver [-json] <command> [flags] [arguments]
+//
+// The commands are:
+//
+//	validate <version>...                     exit 1 unless all versions are valid
+//	compare <a> <b>                           print -1, 0, or 1
+//	sort [-reverse] [<version>...]            sort versions given as arguments or on stdin
+//	filter -r <range> [<version>...]          print the versions that are included in the range
+//	increment [-i <type>] [-preid <id>] <version>
+//	                                          increment a version, type is one of major, minor, patch,
+//	                                          premajor, preminor, prepatch, or prerelease
+//	coerce <text>                             print the first version found in the text
+//	diff <a> <b>                              print the release type that differs between two versions
+//	normalize-range [-d <dialect>] <range>    print the normalized form of a range
+//	bump [-check] [-i <type>] [-preid <id>] [-set <version>] <file>...
+//	                                          check that the versions of package.json, VERSION,
+//	                                          Chart.yaml, Cargo.toml, or Go files are in sync and
+//	                                          rewrite them with an incremented or given version
+//
+// Versions are read from stdin, one per line, when a command that accepts a list of versions is given
+// no versions as arguments. The sort and filter commands ignore a leading "v" so that they can be used
+// on git tags, e.g. "git tag | semver filter -r '^1.2' | semver sort -reverse". The exit code is 0 on success, 1 when the result is negative, e.g. when a
+// version is invalid or when no version matches a range, 2 on usage errors or when an argument
+// cannot be parsed, and 3 when a file cannot be read or written.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/lyraproj/semver/manifest"
+	"github.com/lyraproj/semver/semver"
+)
+
+const (
+	exitOK       = 0
+	exitNegative = 1
+	exitUsage    = 2
+	exitIO       = 3
+)
+
+type cli struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	json   bool
+}
+
+type command struct {
+	name string
+	run  func(c *cli, fs *flag.FlagSet, args []string) int
+}
+
+var commands = []*command{
+	{`validate`, (*cli).validate},
+	{`compare`, (*cli).compare},
+	{`sort`, (*cli).sort},
+	{`filter`, (*cli).filter},
+	{`increment`, (*cli).increment},
+	{`coerce`, (*cli).coerce},
+	{`diff`, (*cli).diff},
+	{`normalize-range`, (*cli).normalizeRange},
+	{`bump`, (*cli).bump},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	c := &cli{stdin: stdin, stdout: stdout, stderr: stderr}
+	fs := flag.NewFlagSet(`semver`, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.BoolVar(&c.json, `json`, false, `produce JSON output`)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, `usage: semver [-json] <command> [flags] [arguments]`)
+		fmt.Fprint(stderr, `commands:`)
+		for _, cmd := range commands {
+			fmt.Fprint(stderr, ` `, cmd.name)
+		}
+		fmt.Fprintln(stderr)
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	name := fs.Arg(0)
+	for _, cmd := range commands {
+		if cmd.name == name {
+			cfs := flag.NewFlagSet(`semver `+name, flag.ContinueOnError)
+			cfs.SetOutput(stderr)
+			cfs.BoolVar(&c.json, `json`, c.json, `produce JSON output`)
+			return cmd.run(c, cfs, fs.Args()[1:])
+		}
+	}
+	fmt.Fprintf(stderr, "semver: unknown command '%s'\n", name)
+	fs.Usage()
+	return exitUsage
+}
+
+func (c *cli) validate(fs *flag.FlagSet, args []string) int {
+	inputs, ok := c.parseArgs(fs, args, -1)
+	if !ok {
+		return exitUsage
+	}
+	type result struct {
+		Input   string `json:"input"`
+		Valid   bool   `json:"valid"`
+		Version string `json:"version,omitempty"`
+		Error   string `json:"error,omitempty"`
+	}
+	results := make([]result, len(inputs))
+	exit := exitOK
+	for idx, s := range inputs {
+		r := result{Input: s}
+		if v, err := semver.ParseVersion(s); err == nil {
+			r.Valid = true
+			r.Version = v.String()
+		} else {
+			r.Error = err.Error()
+			exit = exitNegative
+		}
+		results[idx] = r
+	}
+	if c.json {
+		return c.printJSON(results, exit)
+	}
+	for _, r := range results {
+		if r.Valid {
+			fmt.Fprintln(c.stdout, r.Version)
+		} else {
+			fmt.Fprintln(c.stderr, r.Error)
+		}
+	}
+	return exit
+}
+
+func (c *cli) compare(fs *flag.FlagSet, args []string) int {
+	vs, ok := c.parseVersions(fs, args, 2)
+	if !ok {
+		return exitUsage
+	}
+	cmp := vs[0].CompareTo(vs[1])
+	switch {
+	case cmp < 0:
+		cmp = -1
+	case cmp > 0:
+		cmp = 1
+	}
+	if c.json {
+		return c.printJSON(map[string]interface{}{`a`: vs[0].String(), `b`: vs[1].String(), `result`: cmp}, exitOK)
+	}
+	fmt.Fprintln(c.stdout, cmp)
+	return exitOK
+}
+
+func (c *cli) sort(fs *flag.FlagSet, args []string) int {
+	reverse := fs.Bool(`reverse`, false, `sort in descending order`)
+	vs, ok := c.parseVersionsWith(fs, args, -1, parseTag)
+	if !ok {
+		return exitUsage
+	}
+	semver.SortVersions(vs)
+	if *reverse {
+		for i, j := 0, len(vs)-1; i < j; i, j = i+1, j-1 {
+			vs[i], vs[j] = vs[j], vs[i]
+		}
+	}
+	return c.printVersions(vs, exitOK)
+}
+
+func (c *cli) filter(fs *flag.FlagSet, args []string) int {
+	rs := fs.String(`r`, ``, `the version range`)
+	inputs, ok := c.parseArgs(fs, args, -1)
+	if !ok {
+		return exitUsage
+	}
+	if *rs == `` {
+		fmt.Fprintln(c.stderr, `semver filter: missing -r <range>`)
+		return exitUsage
+	}
+	r, err := semver.ParseVersionRange(*rs)
+	if err != nil {
+		fmt.Fprintln(c.stderr, err)
+		return exitUsage
+	}
+	matches := make([]semver.Version, 0, len(inputs))
+	for _, s := range inputs {
+		// Invalid versions are never included in a range
+		if v, err := parseTag(s); err == nil && r.Includes(v) {
+			matches = append(matches, v)
+		}
+	}
+	exit := exitOK
+	if len(matches) == 0 {
+		exit = exitNegative
+	}
+	return c.printVersions(matches, exit)
+}
+
+func (c *cli) increment(fs *flag.FlagSet, args []string) int {
+	ts := fs.String(`i`, `patch`, `the release type to increment`)
+	preid := fs.String(`preid`, ``, `the identifier used when a pre-release is started`)
+	vs, ok := c.parseVersions(fs, args, 1)
+	if !ok {
+		return exitUsage
+	}
+	t, err := semver.ParseReleaseType(*ts)
+	if err != nil {
+		fmt.Fprintln(c.stderr, err)
+		return exitUsage
+	}
+	v, err := semver.Increment(vs[0], t, *preid)
+	if err != nil {
+		fmt.Fprintln(c.stderr, err)
+		return exitUsage
+	}
+	if c.json {
+		return c.printJSON(map[string]string{`version`: v.String()}, exitOK)
+	}
+	fmt.Fprintln(c.stdout, v)
+	return exitOK
+}
+
+func (c *cli) coerce(fs *flag.FlagSet, args []string) int {
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprintln(c.stderr, `semver coerce: missing text`)
+		return exitUsage
+	}
+	text := strings.Join(fs.Args(), ` `)
+	ms, _ := semver.ScanVersions(strings.NewReader(text))
+	if len(ms) == 0 {
+		if c.json {
+			return c.printJSON(map[string]interface{}{`input`: text, `version`: nil}, exitNegative)
+		}
+		fmt.Fprintf(c.stderr, "no version found in '%s'\n", text)
+		return exitNegative
+	}
+	m := ms[0]
+	if c.json {
+		return c.printJSON(map[string]interface{}{
+			`input`:      text,
+			`version`:    m.Version.String(),
+			`text`:       m.Text,
+			`offset`:     m.Offset,
+			`confidence`: m.Confidence.String()}, exitOK)
+	}
+	fmt.Fprintln(c.stdout, m.Version)
+	return exitOK
+}
+
+func (c *cli) diff(fs *flag.FlagSet, args []string) int {
+	vs, ok := c.parseVersions(fs, args, 2)
+	if !ok {
+		return exitUsage
+	}
+	t, differ := semver.Diff(vs[0], vs[1])
+	exit := exitOK
+	var result interface{}
+	if differ {
+		result = t.String()
+	} else {
+		exit = exitNegative
+	}
+	if c.json {
+		return c.printJSON(map[string]interface{}{`a`: vs[0].String(), `b`: vs[1].String(), `diff`: result}, exit)
+	}
+	if differ {
+		fmt.Fprintln(c.stdout, result)
+	}
+	return exit
+}
+
+func (c *cli) normalizeRange(fs *flag.FlagSet, args []string) int {
+	ds := fs.String(`d`, `npm`, `the dialect of the output`)
+	inputs, ok := c.parseArgs(fs, args, 1)
+	if !ok {
+		return exitUsage
+	}
+	d, err := semver.ParseDialect(*ds)
+	if err != nil {
+		fmt.Fprintln(c.stderr, err)
+		return exitUsage
+	}
+	if inputs[0] == `` {
+		// ParseVersionRange returns a nil range for an empty string
+		fmt.Fprintln(c.stderr, `semver normalize-range: missing <range>`)
+		return exitUsage
+	}
+	r, err := semver.ParseVersionRange(inputs[0])
+	if err != nil {
+		fmt.Fprintln(c.stderr, err)
+		return exitNegative
+	}
+	s, err := semver.RenderVersionRange(r, d)
+	if err != nil {
+		fmt.Fprintln(c.stderr, err)
+		return exitNegative
+	}
+	if c.json {
+		return c.printJSON(map[string]string{`range`: inputs[0], `dialect`: d.String(), `normalized`: s}, exitOK)
+	}
+	fmt.Fprintln(c.stdout, s)
+	return exitOK
+}
+
+func (c *cli) bump(fs *flag.FlagSet, args []string) int {
+	check := fs.Bool(`check`, false, `only check that the versions are in sync`)
+	ts := fs.String(`i`, `patch`, `the release type to increment`)
+	preid := fs.String(`preid`, ``, `the identifier used when a pre-release is started`)
+	set := fs.String(`set`, ``, `the version to write instead of an incremented version`)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprintln(c.stderr, `semver bump: missing files`)
+		return exitUsage
+	}
+	fields, err := manifest.ReadAll(fs.Args())
+	if err != nil {
+		fmt.Fprintln(c.stderr, err)
+		if errors.As(err, new(*os.PathError)) {
+			return exitIO
+		}
+		return exitUsage
+	}
+	current, err := manifest.InSync(fields)
+	if err != nil {
+		fmt.Fprintln(c.stderr, err)
+		return exitNegative
+	}
+	next := current
+	if !*check {
+		if *set != `` {
+			next, err = semver.ParseVersion(strings.TrimPrefix(*set, `v`))
+		} else {
+			var t semver.ReleaseType
+			if t, err = semver.ParseReleaseType(*ts); err == nil {
+				next, err = semver.Increment(current, t, *preid)
+			}
+		}
+		if err != nil {
+			fmt.Fprintln(c.stderr, err)
+			return exitUsage
+		}
+		if err = manifest.WriteAll(fields, next); err != nil {
+			fmt.Fprintln(c.stderr, err)
+			return exitIO
+		}
+	}
+	if c.json {
+		return c.printJSON(map[string]interface{}{`previous`: current.String(), `version`: next.String(), `files`: fs.Args()}, exitOK)
+	}
+	fmt.Fprintln(c.stdout, next)
+	return exitOK
+}
+
+// parseArgs parses the flags of a command and returns its arguments. The arguments are read from stdin
+// when count is negative and no arguments are given. Otherwise exactly count arguments are required.
+func (c *cli) parseArgs(fs *flag.FlagSet, args []string, count int) ([]string, bool) {
+	if err := fs.Parse(args); err != nil {
+		return nil, false
+	}
+	inputs := fs.Args()
+	if count < 0 {
+		if len(inputs) > 0 {
+			return inputs, true
+		}
+		inputs = []string{}
+		s := bufio.NewScanner(c.stdin)
+		for s.Scan() {
+			if line := strings.TrimSpace(s.Text()); line != `` {
+				inputs = append(inputs, line)
+			}
+		}
+		if err := s.Err(); err != nil {
+			fmt.Fprintln(c.stderr, err)
+			return nil, false
+		}
+		return inputs, true
+	}
+	if len(inputs) != count {
+		fmt.Fprintf(c.stderr, "%s: expected %d argument(s), got %d\n", fs.Name(), count, len(inputs))
+		return nil, false
+	}
+	return inputs, true
+}
+
+// parseVersions is like parseArgs but also parses each argument as a version
+func (c *cli) parseVersions(fs *flag.FlagSet, args []string, count int) ([]semver.Version, bool) {
+	return c.parseVersionsWith(fs, args, count, semver.ParseVersion)
+}
+
+// parseVersionsWith is like parseVersions but uses the given function to parse each argument
+func (c *cli) parseVersionsWith(fs *flag.FlagSet, args []string, count int, parse func(string) (semver.Version, error)) ([]semver.Version, bool) {
+	inputs, ok := c.parseArgs(fs, args, count)
+	if !ok {
+		return nil, false
+	}
+	vs := make([]semver.Version, len(inputs))
+	for idx, s := range inputs {
+		v, err := parse(s)
+		if err != nil {
+			fmt.Fprintln(c.stderr, err)
+			return nil, false
+		}
+		vs[idx] = v
+	}
+	return vs, true
+}
+
+// parseTag parses a version that may have a leading "v", such as a git tag like "v1.2.3"
+func parseTag(s string) (semver.Version, error) {
+	return semver.ParseVersion(strings.TrimPrefix(s, `v`))
+}
+
+func (c *cli) printVersions(vs []semver.Version, exit int) int {
+	if c.json {
+		ss := make([]string, len(vs))
+		for idx, v := range vs {
+			ss[idx] = v.String()
+		}
+		return c.printJSON(ss, exit)
+	}
+	for _, v := range vs {
+		fmt.Fprintln(c.stdout, v)
+	}
+	return exit
+}
+
+func (c *cli) printJSON(value interface{}, exit int) int {
+	enc := json.NewEncoder(c.stdout)
+	if err := enc.Encode(value); err != nil {
+		fmt.Fprintln(c.stderr, err)
+		return exitUsage
+	}
+	return exit
+}
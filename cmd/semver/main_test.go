@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func Example_sort() {
+	in := strings.NewReader("v1.10.0\n1.2.0\n\nv1.2.0-rc.1\n")
+	exit := run([]string{`sort`}, in, os.Stdout, os.Stdout)
+	fmt.Println(`exit`, exit)
+	// Output:
+	// 1.2.0-rc.1
+	// 1.2.0
+	// 1.10.0
+	// exit 0
+}
+
+func Example_filter() {
+	exit := run([]string{`-json`, `filter`, `-r`, `^1.2`, `1.1.0`, `1.2.5`, `1.9.0`, `2.0.0`, `bogus`}, nil, os.Stdout, os.Stdout)
+	fmt.Println(`exit`, exit)
+	exit = run([]string{`filter`, `-r`, `>3`, `1.1.0`}, nil, os.Stdout, os.Stdout)
+	fmt.Println(`exit`, exit)
+	exit = run([]string{`filter`, `-r`, `^1.2`}, strings.NewReader("v1.1.0\nv1.2.5\nv2.0.0\n"), os.Stdout, os.Stdout)
+	fmt.Println(`exit`, exit)
+	// Output:
+	// ["1.2.5","1.9.0"]
+	// exit 0
+	// exit 1
+	// 1.2.5
+	// exit 0
+}
+
+func Example_increment() {
+	run([]string{`increment`, `-i`, `premajor`, `--preid`, `rc`, `1.2.3`}, nil, os.Stdout, os.Stdout)
+	run([]string{`increment`, `-json`, `-i`, `prerelease`, `2.0.0-rc.0`}, nil, os.Stdout, os.Stdout)
+	// Output:
+	// 2.0.0-rc.0
+	// {"version":"2.0.0-rc.1"}
+}
+
+func Example_validate() {
+	exit := run([]string{`-json`, `validate`, `1.2.3`, `1.2`}, nil, os.Stdout, os.Stdout)
+	fmt.Println(`exit`, exit)
+	// Output:
+	// [{"input":"1.2.3","valid":true,"version":"1.2.3"},{"input":"1.2","valid":false,"error":"the string '1.2' does not represent a valid semantic version"}]
+	// exit 1
+}
+
+func Example_compare() {
+	run([]string{`compare`, `1.2.3`, `1.10.0`}, nil, os.Stdout, os.Stdout)
+	run([]string{`diff`, `1.2.3`, `1.3.0-rc.1`}, nil, os.Stdout, os.Stdout)
+	run([]string{`coerce`, `tool`, `version`, `v2.4`}, nil, os.Stdout, os.Stdout)
+	run([]string{`-json`, `coerce`, `go1.21.3`}, nil, os.Stdout, os.Stdout)
+	// Output:
+	// -1
+	// preminor
+	// 2.4.0
+	// {"confidence":"strict","input":"go1.21.3","offset":2,"text":"1.21.3","version":"1.21.3"}
+}
+
+func Example_normalizeRange() {
+	run([]string{`normalize-range`, `~1.2 || ^1.5`}, nil, os.Stdout, os.Stdout)
+	run([]string{`normalize-range`, `-d`, `maven`, `^1.2.3`}, nil, os.Stdout, os.Stdout)
+	exit := run([]string{`normalize-range`, ``}, nil, os.Stdout, os.Stdout)
+	fmt.Println(`exit`, exit)
+	exit = run([]string{`frobnicate`}, nil, os.Stdout, os.Stdout)
+	fmt.Println(`exit`, exit)
+	// Output:
+	// >=1.2.0 <1.3.0 || >=1.5.0 <2.0.0
+	// [1.2.3,2.0.0)
+	// semver normalize-range: missing <range>
+	// exit 2
+	// semver: unknown command 'frobnicate'
+	// usage: semver [-json] <command> [flags] [arguments]
+	// commands: validate compare sort filter increment coerce diff normalize-range bump
+	// exit 2
+}
+
+func Example_bump() {
+	dir, err := os.MkdirTemp(``, `semver`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	pkg := filepath.Join(dir, `package.json`)
+	ver := filepath.Join(dir, `VERSION`)
+	os.WriteFile(pkg, []byte("{\n  \"name\": \"app\",\n  \"version\": \"1.4.2\"\n}\n"), 0644)
+	os.WriteFile(ver, []byte("v1.4.2\n"), 0644)
+
+	run([]string{`bump`, `-check`, pkg, ver}, nil, os.Stdout, os.Stdout)
+	run([]string{`bump`, `-i`, `minor`, pkg, ver}, nil, os.Stdout, os.Stdout)
+	content, _ := os.ReadFile(pkg)
+	fmt.Print(string(content))
+	content, _ = os.ReadFile(ver)
+	fmt.Print(string(content))
+
+	os.WriteFile(ver, []byte("1.0.0\n"), 0644)
+	exit := run([]string{`bump`, `-check`, pkg, ver}, nil, os.Stdout, io.Discard)
+	fmt.Println(`exit`, exit)
+	exit = run([]string{`bump`, pkg, filepath.Join(dir, `missing`, `VERSION`)}, nil, os.Stdout, io.Discard)
+	fmt.Println(`exit`, exit)
+	// Output:
+	// 1.4.2
+	// 1.5.0
+	// {
+	//   "name": "app",
+	//   "version": "1.5.0"
+	// }
+	// v1.5.0
+	// exit 1
+	// exit 3
+}
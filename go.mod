@@ -1 +1,3 @@
 module github.com/lyraproj/semver
+
+go 1.16
@@ -0,0 +1,220 @@
+// Package lockfile persists the outcome of a dependency resolution so that later runs can reproduce it. For
+// each package, a lockfile records the requested version range, the resolved version, and integrity data.
+// The file is JSON with its packages sorted by name so that the result of writing it is deterministic and
+// diff-friendly. A range is written as given, e.g. in the syntax of NuGet or Cargo, and when that differs
+// from its normalized form, the normalized form is written as "normalizedRange" and used when reading:
+//
+//	{
+//	  "lockfileVersion": 1,
+//	  "packages": {
+//	    "bar": {
+//	      "range": "^2.0.0",
+//	      "version": "2.1.0",
+//	      "integrity": "sha512-..."
+//	    }
+//	  }
+//	}
+package lockfile
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+// FormatVersion is the version of the lockfile format that is written by Write and understood by Read
+const FormatVersion = 1
+
+// Entry is the locked version of one package
+type Entry struct {
+	// Name is the name of the package
+	Name string
+
+	// Range is the range that was requested for the package or nil if the package was only reached
+	// through other packages
+	Range semver.VersionRange
+
+	// Version is the resolved version
+	Version semver.Version
+
+	// Integrity is a Subresource Integrity string, e.g. "sha512-<base64 digest>", or empty if unknown
+	Integrity string
+}
+
+// Violation describes a package whose locked version no longer satisfies the range that it must satisfy
+type Violation struct {
+	// Name is the name of the package
+	Name string
+
+	// Range is the range that the package must satisfy
+	Range semver.VersionRange
+
+	// Entry is the locked entry or nil if the package is not locked
+	Entry *Entry
+}
+
+// Lockfile is a set of locked packages
+type Lockfile struct {
+	entries map[string]*Entry
+}
+
+type jsonFile struct {
+	LockfileVersion int                   `json:"lockfileVersion"`
+	Packages        map[string]*jsonEntry `json:"packages"`
+}
+
+type jsonEntry struct {
+	Range           string `json:"range,omitempty"`
+	NormalizedRange string `json:"normalizedRange,omitempty"`
+	Version         string `json:"version"`
+	Integrity       string `json:"integrity,omitempty"`
+}
+
+// New returns an empty lockfile
+func New() *Lockfile {
+	return &Lockfile{make(map[string]*Entry)}
+}
+
+// Integrity returns the Subresource Integrity string of the given data using SHA-512
+func Integrity(data []byte) string {
+	sum := sha512.Sum512(data)
+	return `sha512-` + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// Read reads a lockfile
+func Read(r io.Reader) (*Lockfile, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var jf jsonFile
+	if err = json.Unmarshal(data, &jf); err != nil {
+		return nil, err
+	}
+	if jf.LockfileVersion != FormatVersion {
+		return nil, fmt.Errorf(`lockfile version %d is not supported`, jf.LockfileVersion)
+	}
+	l := New()
+	for name, je := range jf.Packages {
+		if je == nil {
+			return nil, fmt.Errorf(`package '%s' has no entry`, name)
+		}
+		e := &Entry{Name: name, Integrity: je.Integrity}
+		if e.Version, err = semver.ParseVersion(je.Version); err != nil {
+			return nil, fmt.Errorf(`package '%s': %s`, name, err.Error())
+		}
+		rs := je.NormalizedRange
+		if rs == `` {
+			rs = je.Range
+		}
+		if rs != `` {
+			if e.Range, err = semver.ParseVersionRange(rs); err != nil {
+				return nil, fmt.Errorf(`package '%s': %s`, name, err.Error())
+			}
+		}
+		l.entries[name] = e
+	}
+	return l, nil
+}
+
+// CheckIntegrity returns an error unless the entry has integrity data that matches the given data
+func (e *Entry) CheckIntegrity(data []byte) error {
+	if e.Integrity == `` {
+		return fmt.Errorf(`%s %s has no integrity data`, e.Name, e.Version)
+	}
+	if e.Integrity != Integrity(data) {
+		return fmt.Errorf(`%s %s does not match its integrity data`, e.Name, e.Version)
+	}
+	return nil
+}
+
+func (v *Violation) String() string {
+	if v.Entry == nil {
+		return fmt.Sprintf(`%s is not locked but must satisfy %s`, v.Name, v.Range)
+	}
+	return fmt.Sprintf(`%s %s does not satisfy %s`, v.Name, v.Entry.Version, v.Range)
+}
+
+// Entries returns all entries sorted by name
+func (l *Lockfile) Entries() []*Entry {
+	es := make([]*Entry, 0, len(l.entries))
+	for _, e := range l.entries {
+		es = append(es, e)
+	}
+	sort.Slice(es, func(i, j int) bool { return es[i].Name < es[j].Name })
+	return es
+}
+
+// Get returns the entry of the package with the given name and true, or nil and false if the package
+// isn't locked
+func (l *Lockfile) Get(name string) (*Entry, bool) {
+	e, ok := l.entries[name]
+	return e, ok
+}
+
+// Remove removes the entry of the package with the given name
+func (l *Lockfile) Remove(name string) {
+	delete(l.entries, name)
+}
+
+// Set adds the given entry, replacing any entry with the same name
+func (l *Lockfile) Set(e *Entry) {
+	l.entries[e.Name] = e
+}
+
+// Verify returns the packages whose locked version does not satisfy their range, sorted by name. The
+// given ranges are the current requirements, typically read from a manifest, and they take precedence
+// over the ranges recorded in the lockfile. A package that is in the given ranges but not locked is also
+// a violation. An empty slice means that the lockfile can be used as is.
+func (l *Lockfile) Verify(ranges map[string]semver.VersionRange) []*Violation {
+	vs := make([]*Violation, 0)
+	for name, e := range l.entries {
+		r, ok := ranges[name]
+		if !ok {
+			r = e.Range
+		}
+		if r != nil && !r.Includes(e.Version) {
+			vs = append(vs, &Violation{Name: name, Range: r, Entry: e})
+		}
+	}
+	for name, r := range ranges {
+		if _, ok := l.entries[name]; !ok {
+			vs = append(vs, &Violation{Name: name, Range: r})
+		}
+	}
+	sort.Slice(vs, func(i, j int) bool { return vs[i].Name < vs[j].Name })
+	return vs
+}
+
+// Write writes the lockfile. The output only depends on the entries, never on the order in which they
+// were added.
+func (l *Lockfile) Write(w io.Writer) error {
+	jf := &jsonFile{LockfileVersion: FormatVersion, Packages: make(map[string]*jsonEntry, len(l.entries))}
+	for name, e := range l.entries {
+		je := &jsonEntry{Version: e.Version.String(), Integrity: e.Integrity}
+		if e.Range != nil {
+			je.Range = e.Range.String()
+			if n := e.Range.NormalizedString(); n != je.Range {
+				je.NormalizedRange = n
+			}
+		}
+		jf.Packages[name] = je
+	}
+
+	// The encoder sorts map keys. HTML escaping is turned off so that ranges like ">=1.0.0" stay readable.
+	bld := bytes.NewBufferString(``)
+	enc := json.NewEncoder(bld)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent(``, `  `)
+	if err := enc.Encode(jf); err != nil {
+		return err
+	}
+	_, err := w.Write(bld.Bytes())
+	return err
+}
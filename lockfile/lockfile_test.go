@@ -0,0 +1,109 @@
+package lockfile_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/lyraproj/semver/lockfile"
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleLockfile_Write() {
+	l := lockfile.New()
+	l.Set(&lockfile.Entry{
+		Name:    `foo`,
+		Range:   semver.MustParseVersionRange(`>=1.2 <2`),
+		Version: semver.MustParseVersion(`1.4.0`),
+	})
+	l.Set(&lockfile.Entry{
+		Name:      `bar`,
+		Version:   semver.MustParseVersion(`2.1.0`),
+		Integrity: `sha512-3q2+7w==`,
+	})
+	if err := l.Write(os.Stdout); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// {
+	//   "lockfileVersion": 1,
+	//   "packages": {
+	//     "bar": {
+	//       "version": "2.1.0",
+	//       "integrity": "sha512-3q2+7w=="
+	//     },
+	//     "foo": {
+	//       "range": ">=1.2 <2",
+	//       "normalizedRange": ">=1.2.0 <2.0.0",
+	//       "version": "1.4.0"
+	//     }
+	//   }
+	// }
+}
+
+func ExampleRead_dialect() {
+	l := lockfile.New()
+	nr, _ := semver.ParseNuGetVersionRange(`[1.0, 2.0)`)
+	cr, _ := semver.ParseCargoVersionRange(`1.2`)
+	l.Set(&lockfile.Entry{Name: `foo`, Range: nr, Version: semver.MustParseVersion(`1.4.0`)})
+	l.Set(&lockfile.Entry{Name: `bar`, Range: cr, Version: semver.MustParseVersion(`1.5.0`)})
+	bld := &strings.Builder{}
+	l.Write(bld)
+	l, err := lockfile.Read(strings.NewReader(bld.String()))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, e := range l.Entries() {
+		fmt.Println(e.Name, e.Range)
+	}
+	fmt.Println(len(l.Verify(nil)))
+	// Output:
+	// bar >=1.2.0 <2.0.0
+	// foo >=1.0.0 <2.0.0
+	// 0
+}
+
+func ExampleLockfile_Verify() {
+	l, err := lockfile.Read(strings.NewReader(`{
+  "lockfileVersion": 1,
+  "packages": {
+    "bar": { "range": "^2.0.0", "version": "1.9.0" },
+    "baz": { "version": "3.0.0" },
+    "foo": { "range": "^1.2.0", "version": "1.4.0" }
+  }
+}`))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, v := range l.Verify(map[string]semver.VersionRange{
+		`foo`: semver.MustParseVersionRange(`^1.5.0`),
+		`qux`: semver.MustParseVersionRange(`~0.3.1`),
+	}) {
+		fmt.Println(v)
+	}
+	// Output:
+	// bar 1.9.0 does not satisfy ^2.0.0
+	// foo 1.4.0 does not satisfy ^1.5.0
+	// qux is not locked but must satisfy ~0.3.1
+}
+
+func ExampleEntry_CheckIntegrity() {
+	e := &lockfile.Entry{Name: `foo`, Version: semver.MustParseVersion(`1.0.0`), Integrity: lockfile.Integrity([]byte(`content`))}
+	fmt.Println(e.CheckIntegrity([]byte(`content`)))
+	fmt.Println(e.CheckIntegrity([]byte(`tampered`)))
+	// Output:
+	// <nil>
+	// foo 1.0.0 does not match its integrity data
+}
+
+func ExampleRead() {
+	_, err := lockfile.Read(strings.NewReader(`{"lockfileVersion": 2, "packages": {}}`))
+	fmt.Println(err)
+	_, err = lockfile.Read(strings.NewReader(`{"lockfileVersion": 1, "packages": {"foo": {"version": "1.x"}}}`))
+	fmt.Println(err)
+	// Output:
+	// lockfile version 2 is not supported
+	// package 'foo': the string '1.x' does not represent a valid semantic version
+}
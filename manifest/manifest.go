@@ -0,0 +1,364 @@
+// Package manifest locates and rewrites the version fields of files such as package.json, VERSION,
+// Chart.yaml, Cargo.toml, and Go source files that declare a version constant.
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+// A Format knows how to locate the version field in one kind of file
+type Format interface {
+	// Name returns the name of the format, e.g. "package.json"
+	Name() string
+
+	// Matches returns true if the file with the given path uses this format
+	Matches(path string) bool
+
+	// Locate returns the start and end offsets of the version text in the given content
+	Locate(content []byte) (int, int, error)
+}
+
+// A Field is a version field found in a file
+type Field struct {
+	// Path is the path of the file
+	Path string
+
+	// Format is the format of the file
+	Format Format
+
+	// Offset is the byte offset of the version text in the file
+	Offset int
+
+	// Text is the version text as written in the file, e.g. "v1.2.3"
+	Text string
+
+	// Version is the version that the text represents
+	Version semver.Version
+}
+
+type format struct {
+	name    string
+	matches func(base string) bool
+	locate  func(content []byte) (int, int, error)
+}
+
+// Formats are the known formats in the order they are tried
+var Formats = []Format{
+	&format{`package.json`, baseIs(`package.json`), locatePackageJSON},
+	&format{`VERSION`, baseIs(`VERSION`, `VERSION.txt`), locateVersionFile},
+	&format{`Chart.yaml`, baseIs(`Chart.yaml`), locateChart},
+	&format{`toml`, baseIs(`Cargo.toml`, `pyproject.toml`), locateToml},
+	&format{`go`, func(base string) bool { return strings.HasSuffix(base, `.go`) }, locateGoConst},
+}
+
+// FormatOf returns the format of the file with the given path or an error if the format is unknown
+func FormatOf(path string) (Format, error) {
+	for _, f := range Formats {
+		if f.Matches(path) {
+			return f, nil
+		}
+	}
+	return nil, fmt.Errorf(`the file '%s' has no known version format`, path)
+}
+
+// Read returns the version field of the file with the given path
+func Read(path string) (*Field, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(path, content)
+}
+
+// Parse returns the version field of the given content. The path determines the format.
+func Parse(path string, content []byte) (*Field, error) {
+	f, err := FormatOf(path)
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := f.Locate(content)
+	if err != nil {
+		return nil, fmt.Errorf(`%s: %s`, path, err.Error())
+	}
+	text := string(content[start:end])
+	v, err := semver.ParseVersion(strings.TrimPrefix(text, `v`))
+	if err != nil {
+		return nil, fmt.Errorf(`%s: %s`, path, err.Error())
+	}
+	return &Field{Path: path, Format: f, Offset: start, Text: text, Version: v}, nil
+}
+
+// ReadAll returns the version fields of all files with the given paths
+func ReadAll(paths []string) ([]*Field, error) {
+	fields := make([]*Field, len(paths))
+	for idx, path := range paths {
+		f, err := Read(path)
+		if err != nil {
+			return nil, err
+		}
+		fields[idx] = f
+	}
+	return fields, nil
+}
+
+// InSync returns the version of the given fields or an error listing the fields when their versions
+// are not equal. Build metadata is included in the comparison.
+func InSync(fields []*Field) (semver.Version, error) {
+	if len(fields) == 0 {
+		return nil, fmt.Errorf(`no version fields given`)
+	}
+	v := fields[0].Version
+	for _, f := range fields[1:] {
+		if !f.Version.Equals(v) {
+			bld := bytes.NewBufferString(`the versions are not in sync:`)
+			for _, f := range fields {
+				fmt.Fprintf(bld, ` %s=%s`, f.Path, f.Version)
+			}
+			return nil, fmt.Errorf(`%s`, bld.String())
+		}
+	}
+	return v, nil
+}
+
+// Replace returns a copy of the given content where the version text of the field has been replaced with
+// the given version. A "v" prefix in the original text is retained. All other content is left intact.
+func Replace(content []byte, f *Field, v semver.Version) ([]byte, error) {
+	end := f.Offset + len(f.Text)
+	if end > len(content) || string(content[f.Offset:end]) != f.Text {
+		return nil, fmt.Errorf(`%s: the version field has changed since it was read`, f.Path)
+	}
+	text := v.String()
+	if strings.HasPrefix(f.Text, `v`) {
+		text = `v` + text
+	}
+	result := make([]byte, 0, len(content)+len(text)-len(f.Text))
+	result = append(result, content[:f.Offset]...)
+	result = append(result, text...)
+	return append(result, content[end:]...), nil
+}
+
+// Write replaces the version of the field in its file with the given version. The new content is written
+// to a temporary file in the same directory which is then renamed, so the original file is left intact if
+// the write is interrupted.
+func Write(f *Field, v semver.Version) error {
+	u, err := prepare(f, v)
+	if err != nil {
+		return err
+	}
+	return writeAtomic(u.path, u.content, u.mode)
+}
+
+// WriteAll replaces the versions of all fields with the given version. The new content of every file is
+// prepared before any file is written, so no file is changed when one of them cannot be read or when its
+// version field has changed since it was read. When writing a file fails, the error lists the files that
+// were already updated.
+func WriteAll(fields []*Field, v semver.Version) error {
+	updates := make([]*update, len(fields))
+	for idx, f := range fields {
+		u, err := prepare(f, v)
+		if err != nil {
+			return err
+		}
+		updates[idx] = u
+	}
+	for idx, u := range updates {
+		if err := writeAtomic(u.path, u.content, u.mode); err != nil {
+			if idx == 0 {
+				return err
+			}
+			done := make([]string, idx)
+			for i, d := range updates[:idx] {
+				done[i] = d.path
+			}
+			return fmt.Errorf(`%s (already updated: %s)`, err.Error(), strings.Join(done, `, `))
+		}
+	}
+	return nil
+}
+
+// update is the new content of a file
+type update struct {
+	path    string
+	content []byte
+	mode    os.FileMode
+}
+
+// prepare reads the file of the field and returns its content with the version replaced
+func prepare(f *Field, v semver.Version) (*update, error) {
+	info, err := os.Stat(f.Path)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(f.Path)
+	if err != nil {
+		return nil, err
+	}
+	if content, err = Replace(content, f, v); err != nil {
+		return nil, err
+	}
+	return &update{f.Path, content, info.Mode()}, nil
+}
+
+// writeAtomic writes the content to a temporary file in the directory of the given path and renames it
+// to that path
+func writeAtomic(path string, content []byte, mode os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), `.`+filepath.Base(path)+`.*`)
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(content)
+	if err == nil {
+		err = tmp.Chmod(mode)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+func (f *format) Name() string {
+	return f.name
+}
+
+func (f *format) Matches(path string) bool {
+	return f.matches(filepath.Base(path))
+}
+
+func (f *format) Locate(content []byte) (int, int, error) {
+	return f.locate(content)
+}
+
+func baseIs(names ...string) func(string) bool {
+	return func(base string) bool {
+		for _, n := range names {
+			if base == n {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// locatePackageJSON locates the value of the top level "version" property
+func locatePackageJSON(content []byte) (int, int, error) {
+	dec := json.NewDecoder(bytes.NewReader(content))
+	depth := 0
+	key := true
+	for {
+		t, err := dec.Token()
+		if err != nil {
+			return 0, 0, fmt.Errorf(`no top level "version" property found`)
+		}
+		switch t := t.(type) {
+		case json.Delim:
+			if t == '{' || t == '[' {
+				depth++
+			} else {
+				depth--
+			}
+			key = depth == 1
+			continue
+		case string:
+			if depth == 1 && key && t == `version` {
+				vt, err := dec.Token()
+				if err != nil {
+					return 0, 0, err
+				}
+				vs, ok := vt.(string)
+				end := int(dec.InputOffset()) - 1
+				start := end - len(vs)
+				if !ok || start < 0 || string(content[start:end]) != vs {
+					return 0, 0, fmt.Errorf(`the "version" property is not a plain string`)
+				}
+				return start, end, nil
+			}
+		}
+		if depth == 1 {
+			key = !key
+		}
+	}
+}
+
+func locateVersionFile(content []byte) (int, int, error) {
+	start := len(content) - len(bytes.TrimLeft(content, " \t\r\n"))
+	end := len(bytes.TrimRight(content, " \t\r\n"))
+	if start >= end || bytes.ContainsAny(content[start:end], " \t\r\n") {
+		return 0, 0, fmt.Errorf(`a VERSION file must contain a single version`)
+	}
+	return start, end, nil
+}
+
+var chartVersionPattern = regexp.MustCompile(`(?m)^version:[ \t]*["']?([^"'\s#]+)`)
+
+func locateChart(content []byte) (int, int, error) {
+	m := chartVersionPattern.FindSubmatchIndex(content)
+	if m == nil {
+		return 0, 0, fmt.Errorf(`no top level "version" key found`)
+	}
+	return m[2], m[3], nil
+}
+
+var tomlSectionPattern = regexp.MustCompile(`\A\s*\[([^\[\]]+)\]`)
+var tomlVersionPattern = regexp.MustCompile(`\A\s*version\s*=\s*"([^"]*)"`)
+var tomlVersionSections = map[string]bool{``: true, `package`: true, `workspace.package`: true, `project`: true, `tool.poetry`: true}
+
+// locateToml locates the first version = "..." line in a [package] or [project] section
+func locateToml(content []byte) (int, int, error) {
+	section := ``
+	offset := 0
+	for _, line := range bytes.SplitAfter(content, []byte("\n")) {
+		if m := tomlSectionPattern.FindSubmatch(line); m != nil {
+			section = strings.TrimSpace(string(m[1]))
+		} else if m := tomlVersionPattern.FindSubmatchIndex(line); m != nil && tomlVersionSections[section] {
+			return offset + m[2], offset + m[3], nil
+		}
+		offset += len(line)
+	}
+	return 0, 0, fmt.Errorf(`no version = "..." line found in a package section`)
+}
+
+// goVersionSpec matches a constant specification of a string named Version or ending with Version
+const goVersionSpec = `[A-Za-z_]*[Vv]ersion[ \t]*(?:string[ \t]*)?=[ \t]*"([^"]*)"`
+
+var goConstPattern = regexp.MustCompile(`(?m)^const[ \t]+` + goVersionSpec)
+var goConstBlockPattern = regexp.MustCompile(`(?ms)^const[ \t]*\(.*?^\)`)
+var goConstSpecPattern = regexp.MustCompile(`(?m)^[ \t]+` + goVersionSpec)
+
+// locateGoConst locates the first top-level constant declaration of a string named Version or ending with
+// Version. Variables and constants declared in function bodies are not considered.
+func locateGoConst(content []byte) (int, int, error) {
+	start, end := -1, -1
+	if m := goConstPattern.FindSubmatchIndex(content); m != nil {
+		start, end = m[2], m[3]
+	}
+	for _, b := range goConstBlockPattern.FindAllIndex(content, -1) {
+		if start >= 0 && b[0] > start {
+			break
+		}
+		if m := goConstSpecPattern.FindSubmatchIndex(content[b[0]:b[1]]); m != nil {
+			start, end = b[0]+m[2], b[0]+m[3]
+			break
+		}
+	}
+	if start < 0 {
+		return 0, 0, fmt.Errorf(`no version constant found`)
+	}
+	return start, end, nil
+}
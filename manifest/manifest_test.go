@@ -0,0 +1,165 @@
+package manifest_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lyraproj/semver/manifest"
+	"github.com/lyraproj/semver/semver"
+)
+
+var files = map[string]string{
+	`package.json`: `{
+  "name": "app",
+  "engines": {"version": "0.0.1"},
+  "version": "1.4.2",
+  "dependencies": {"left-pad": "^1.3.0"}
+}
+`,
+	`VERSION`: "1.4.2\n",
+	`charts/app/Chart.yaml`: `apiVersion: v2
+name: app
+version: 1.4.2 # bumped by release job
+appVersion: "1.4.2"
+`,
+	`Cargo.toml`: `[dependencies]
+serde = { version = "1.0" }
+
+[package]
+name = "app"
+version = "1.4.2"  # keep in sync
+`,
+	`version.go`: `package app
+
+// Version is the version of the app
+const Version = "v1.4.2"
+`,
+}
+
+var order = []string{`package.json`, `VERSION`, `charts/app/Chart.yaml`, `Cargo.toml`, `version.go`}
+
+func ExampleParse() {
+	fields := make([]*manifest.Field, 0, len(order))
+	for _, path := range order {
+		f, err := manifest.Parse(path, []byte(files[path]))
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(f.Format.Name(), f.Offset, f.Text)
+		fields = append(fields, f)
+	}
+	fmt.Println(manifest.InSync(fields))
+	// Output:
+	// package.json 68 1.4.2
+	// VERSION 0 1.4.2
+	// Chart.yaml 34 1.4.2
+	// toml 78 1.4.2
+	// go 67 v1.4.2
+	// 1.4.2 <nil>
+}
+
+func ExampleReplace() {
+	for _, path := range []string{`Cargo.toml`, `version.go`} {
+		content := []byte(files[path])
+		f, _ := manifest.Parse(path, content)
+		content, err := manifest.Replace(content, f, semver.MustParseVersion(`1.5.0`))
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Print(string(content))
+	}
+	// Output:
+	// [dependencies]
+	// serde = { version = "1.0" }
+	//
+	// [package]
+	// name = "app"
+	// version = "1.5.0"  # keep in sync
+	// package app
+	//
+	// // Version is the version of the app
+	// const Version = "v1.5.0"
+}
+
+func ExampleInSync() {
+	a, _ := manifest.Parse(`VERSION`, []byte(`1.4.2`))
+	b, _ := manifest.Parse(`package.json`, []byte(`{"version":"1.5.0"}`))
+	_, err := manifest.InSync([]*manifest.Field{a, b})
+	fmt.Println(err)
+	// Output: the versions are not in sync: VERSION=1.4.2 package.json=1.5.0
+}
+
+func ExampleWrite() {
+	dir, err := os.MkdirTemp(``, `manifest`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, `VERSION`)
+	os.WriteFile(path, []byte("v1.4.2\n"), 0600)
+
+	f, err := manifest.Read(path)
+	if err == nil {
+		err = manifest.Write(f, semver.MustParseVersion(`1.5.0`))
+	}
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	content, _ := os.ReadFile(path)
+	info, _ := os.Stat(path)
+	entries, _ := os.ReadDir(dir)
+	fmt.Printf("%q %s %d\n", content, info.Mode(), len(entries))
+	// Output: "v1.5.0\n" -rw------- 1
+}
+
+func ExampleWriteAll() {
+	dir, err := os.MkdirTemp(``, `manifest`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	ver := filepath.Join(dir, `VERSION`)
+	chart := filepath.Join(dir, `Chart.yaml`)
+	os.WriteFile(ver, []byte("1.4.2\n"), 0644)
+	os.WriteFile(chart, []byte("name: app\nversion: 1.4.2\n"), 0644)
+
+	fields, err := manifest.ReadAll([]string{ver, chart})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	os.WriteFile(chart, []byte("name: app\nversion: 1.4.3\n"), 0644)
+	err = manifest.WriteAll(fields, semver.MustParseVersion(`1.5.0`))
+	fmt.Println(strings.TrimPrefix(err.Error(), dir))
+	content, _ := os.ReadFile(ver)
+	fmt.Printf("%q\n", content)
+	// Output:
+	// /Chart.yaml: the version field has changed since it was read
+	// "1.4.2\n"
+}
+
+func ExampleParse_goConst() {
+	for _, src := range []string{
+		"package app\n\nvar apiVersion = \"1.0.0\"\n\nfunc init() {\n\tschemaVersion = \"2.0.0\"\n}\n\nconst Version = \"1.4.2\"\n",
+		"package app\n\nconst (\n\tName    = \"app\"\n\tVersion = \"v1.4.2\"\n)\n",
+		"package app\n\nfunc f() {\n\tconst Version = \"1.0.0\"\n}\n",
+	} {
+		f, err := manifest.Parse(`version.go`, []byte(src))
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Println(f.Offset, f.Text)
+	}
+	// Output:
+	// 98 1.4.2
+	// 50 v1.4.2
+	// version.go: no version constant found
+}
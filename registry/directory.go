@@ -0,0 +1,118 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+type directoryRegistry struct {
+	dir string
+}
+
+// index is the JSON representation of a package in a directory registry
+type index struct {
+	DistTags map[string]string       `json:"dist-tags"`
+	Versions map[string]indexVersion `json:"versions"`
+}
+
+type indexVersion struct {
+	Dependencies map[string]string `json:"dependencies,omitempty"`
+	Yanked       bool              `json:"yanked,omitempty"`
+	Deprecated   string            `json:"deprecated,omitempty"`
+}
+
+// NewDirectoryRegistry returns a registry that reads the index of a package from the file "<name>.json"
+// in the given directory. A scoped name such as "@scope/foo" is read from "@scope/foo.json". The files
+// are read on each call so changes are visible immediately. An index file looks like this:
+//
+//	{
+//	  "dist-tags": { "latest": "1.2.0" },
+//	  "versions": {
+//	    "1.0.0": { "dependencies": { "bar": "^2.0.0" }, "yanked": true },
+//	    "1.1.0": { "dependencies": { "bar": "^2.1.0" }, "deprecated": "use 1.2.0" },
+//	    "1.2.0": { "dependencies": { "bar": "^2.1.0" } }
+//	  }
+//	}
+func NewDirectoryRegistry(dir string) Registry {
+	return &directoryRegistry{dir}
+}
+
+func (r *directoryRegistry) Versions(name string) ([]semver.Version, error) {
+	p, err := r.read(name)
+	if err != nil {
+		return nil, err
+	}
+	return p.versionList(), nil
+}
+
+func (r *directoryRegistry) Metadata(name string, v semver.Version) (*Metadata, error) {
+	p, err := r.read(name)
+	if err != nil {
+		return nil, err
+	}
+	return p.find(name, v)
+}
+
+func (r *directoryRegistry) DistTags(name string) (map[string]semver.Version, error) {
+	p, err := r.read(name)
+	if err != nil {
+		return nil, err
+	}
+	return p.tagMap(), nil
+}
+
+// read returns the package with the given name, or nil if the directory has no index for it
+func (r *directoryRegistry) read(name string) (*pkg, error) {
+	if name == `` || strings.Contains(name, `..`) || strings.ContainsAny(name, `\:`) {
+		return nil, fmt.Errorf(`'%s' is not a valid package name`, name)
+	}
+	file := filepath.Join(r.dir, filepath.FromSlash(name)+`.json`)
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var ix index
+	if err = json.Unmarshal(data, &ix); err != nil {
+		return nil, fmt.Errorf(`%s: %s`, file, err.Error())
+	}
+
+	p := newPkg()
+	for vs, iv := range ix.Versions {
+		v, err := semver.ParseVersion(vs)
+		if err != nil {
+			return nil, fmt.Errorf(`%s: %s`, file, err.Error())
+		}
+		m := &Metadata{
+			Version:      v,
+			Dependencies: make(map[string]semver.VersionRange, len(iv.Dependencies)),
+			Yanked:       iv.Yanked,
+			Deprecated:   iv.Deprecated}
+		for dep, rs := range iv.Dependencies {
+			if m.Dependencies[dep], err = semver.ParseVersionRange(rs); err != nil {
+				return nil, fmt.Errorf(`%s: %s`, file, err.Error())
+			}
+		}
+		if err = p.add(name, m); err != nil {
+			return nil, fmt.Errorf(`%s: %s`, file, err.Error())
+		}
+	}
+	for tag, vs := range ix.DistTags {
+		v, err := semver.ParseVersion(vs)
+		if err == nil {
+			_, err = p.find(name, v)
+		}
+		if err != nil {
+			return nil, fmt.Errorf(`%s: dist-tag '%s': %s`, file, tag, err.Error())
+		}
+		p.tags[tag] = v
+	}
+	return p, nil
+}
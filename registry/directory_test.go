@@ -0,0 +1,50 @@
+package registry_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lyraproj/semver/registry"
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleNewDirectoryRegistry() {
+	dir, err := os.MkdirTemp(``, `registry`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	os.Mkdir(filepath.Join(dir, `@acme`), 0755)
+	os.WriteFile(filepath.Join(dir, `@acme`, `foo.json`), []byte(`{
+  "dist-tags": { "latest": "1.1.0" },
+  "versions": {
+    "1.0.0": { "dependencies": { "bar": "^2.0.0" }, "yanked": true },
+    "1.1.0": { "dependencies": { "bar": "^2.1.0" } }
+  }
+}`), 0644)
+
+	os.WriteFile(filepath.Join(dir, `baz.json`), []byte(`{ "versions": { "1.0.0": { "dependencies": { "bar": "" } } } }`), 0644)
+
+	r := registry.NewDirectoryRegistry(dir)
+	fmt.Println(r.Versions(`@acme/foo`))
+	fmt.Println(r.DistTags(`@acme/foo`))
+	m, _ := r.Metadata(`@acme/foo`, semver.MustParseVersion(`1.0.0`))
+	fmt.Println(m.Yanked, m.Dependencies[`bar`])
+	fmt.Println(registry.MaxSatisfying(r, `@acme/foo`, semver.MustParseVersionRange(`<1.1.0`)))
+	fmt.Println(r.Versions(`bar`))
+	_, err = r.Versions(`../secrets`)
+	fmt.Println(err)
+	_, err = r.Versions(`baz`)
+	fmt.Println(strings.TrimPrefix(err.Error(), dir))
+	// Output:
+	// [1.0.0 1.1.0] <nil>
+	// map[latest:1.1.0] <nil>
+	// true ^2.0.0
+	// <nil> <nil>
+	// [] <nil>
+	// '../secrets' is not a valid package name
+	// /baz.json: the dependency 'bar' of version 1.0.0 of package 'baz' has no version range
+}
@@ -0,0 +1,130 @@
+package registry
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+type memoryRegistry struct {
+	lock     sync.RWMutex
+	packages map[string]*pkg
+}
+
+// pkg holds the versions and dist-tags of one package
+type pkg struct {
+	versions []semver.Version
+	metadata map[string]*Metadata
+	tags     map[string]semver.Version
+}
+
+// NewMemoryRegistry returns an empty registry that keeps all packages in memory. It is safe for
+// concurrent use.
+func NewMemoryRegistry() MutableRegistry {
+	return &memoryRegistry{packages: make(map[string]*pkg)}
+}
+
+func (r *memoryRegistry) Versions(name string) ([]semver.Version, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.packages[name].versionList(), nil
+}
+
+func (r *memoryRegistry) Metadata(name string, v semver.Version) (*Metadata, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	m, err := r.packages[name].find(name, v)
+	if err != nil {
+		return nil, err
+	}
+	return m.copy(), nil
+}
+
+func (r *memoryRegistry) DistTags(name string) (map[string]semver.Version, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.packages[name].tagMap(), nil
+}
+
+func (r *memoryRegistry) Publish(name string, m *Metadata) error {
+	if m == nil || m.Version == nil {
+		return fmt.Errorf(`the metadata published for package '%s' has no version`, name)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	p, ok := r.packages[name]
+	if !ok {
+		p = newPkg()
+		r.packages[name] = p
+	}
+	return p.add(name, m)
+}
+
+func (r *memoryRegistry) SetYanked(name string, v semver.Version, yanked bool) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	m, err := r.packages[name].find(name, v)
+	if err != nil {
+		return err
+	}
+	m.Yanked = yanked
+	return nil
+}
+
+func (r *memoryRegistry) SetDistTag(name, tag string, v semver.Version) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	p := r.packages[name]
+	if _, err := p.find(name, v); err != nil {
+		return err
+	}
+	p.tags[tag] = v
+	return nil
+}
+
+func newPkg() *pkg {
+	return &pkg{metadata: make(map[string]*Metadata), tags: make(map[string]semver.Version)}
+}
+
+func (p *pkg) add(name string, m *Metadata) error {
+	key := m.Version.String()
+	if _, ok := p.metadata[key]; ok {
+		return fmt.Errorf(`version %s of package '%s' is already published`, key, name)
+	}
+	for dep, r := range m.Dependencies {
+		if r == nil {
+			return fmt.Errorf(`the dependency '%s' of version %s of package '%s' has no version range`, dep, key, name)
+		}
+	}
+	p.metadata[key] = m.copy()
+	p.versions = append(p.versions, m.Version)
+	semver.SortVersions(p.versions)
+	return nil
+}
+
+func (p *pkg) find(name string, v semver.Version) (*Metadata, error) {
+	if p != nil {
+		if m, ok := p.metadata[v.String()]; ok {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf(`version %s of package '%s' is not published`, v, name)
+}
+
+func (p *pkg) versionList() []semver.Version {
+	if p == nil {
+		return []semver.Version{}
+	}
+	return append([]semver.Version{}, p.versions...)
+}
+
+func (p *pkg) tagMap() map[string]semver.Version {
+	result := make(map[string]semver.Version)
+	if p != nil {
+		for tag, v := range p.tags {
+			result[tag] = v
+		}
+	}
+	return result
+}
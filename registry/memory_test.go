@@ -0,0 +1,55 @@
+package registry_test
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/semver/registry"
+	"github.com/lyraproj/semver/semver"
+)
+
+func publish(r registry.MutableRegistry, name string, versions ...string) {
+	for _, v := range versions {
+		if err := r.Publish(name, &registry.Metadata{Version: semver.MustParseVersion(v)}); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func ExampleNewMemoryRegistry() {
+	r := registry.NewMemoryRegistry()
+	publish(r, `foo`, `1.10.0`, `1.2.0`, `2.0.0-rc.1`, `1.9.0`)
+	fmt.Println(r.SetYanked(`foo`, semver.MustParseVersion(`1.10.0`), true))
+	fmt.Println(r.SetDistTag(`foo`, `next`, semver.MustParseVersion(`2.0.0-rc.1`)))
+	fmt.Println(r.Versions(`foo`))
+	fmt.Println(r.DistTags(`foo`))
+	fmt.Println(r.Publish(`foo`, &registry.Metadata{Version: semver.MustParseVersion(`1.2.0`)}))
+	_, err := r.Metadata(`bar`, semver.MustParseVersion(`1.0.0`))
+	fmt.Println(err)
+	// Output:
+	// <nil>
+	// <nil>
+	// [1.2.0 1.9.0 1.10.0 2.0.0-rc.1] <nil>
+	// map[next:2.0.0-rc.1] <nil>
+	// version 1.2.0 of package 'foo' is already published
+	// version 1.0.0 of package 'bar' is not published
+}
+
+func ExampleMutableRegistry_Publish() {
+	r := registry.NewMemoryRegistry()
+	deps := map[string]semver.VersionRange{`bar`: semver.MustParseVersionRange(`^2.0.0`)}
+	fmt.Println(r.Publish(`foo`, &registry.Metadata{Version: semver.MustParseVersion(`1.0.0`), Dependencies: deps}))
+	deps[`baz`] = semver.MustParseVersionRange(`^3.0.0`)
+	m, _ := r.Metadata(`foo`, semver.MustParseVersion(`1.0.0`))
+	m.Dependencies[`qux`] = semver.MustParseVersionRange(`^4.0.0`)
+	m, _ = r.Metadata(`foo`, semver.MustParseVersion(`1.0.0`))
+	fmt.Println(len(m.Dependencies))
+	fmt.Println(r.Publish(`foo`, &registry.Metadata{
+		Version:      semver.MustParseVersion(`1.1.0`),
+		Dependencies: map[string]semver.VersionRange{`bar`: nil}}))
+	fmt.Println(r.Publish(`foo`, nil))
+	// Output:
+	// <nil>
+	// 1
+	// the dependency 'bar' of version 1.1.0 of package 'foo' has no version range
+	// the metadata published for package 'foo' has no version
+}
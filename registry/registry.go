@@ -0,0 +1,175 @@
+// Package registry provides access to the published versions of packages along with their dependencies,
+// dist-tags, and yanked status. It contains an in-memory implementation and an implementation that reads
+// a directory of JSON index files, which makes it possible to test resolution and upgrade tooling offline.
+package registry
+
+import (
+	"github.com/lyraproj/semver/resolver"
+	"github.com/lyraproj/semver/semver"
+)
+
+// Metadata describes one published version of a package
+type Metadata struct {
+	// Version is the version
+	Version semver.Version
+
+	// Dependencies maps the names of the packages that this version depends on to version ranges
+	Dependencies map[string]semver.VersionRange
+
+	// Yanked is true when the version has been withdrawn. A yanked version remains available to those
+	// who already depend on it but is never selected for new dependencies unless a range pins it exactly.
+	Yanked bool
+
+	// Deprecated is the deprecation message of the version or empty if the version is not deprecated. A
+	// deprecated version is selected under the same rules as a yanked version.
+	Deprecated string
+}
+
+// copy returns a copy of the metadata that doesn't share its dependency map with the original
+func (m *Metadata) copy() *Metadata {
+	cm := *m
+	if m.Dependencies != nil {
+		cm.Dependencies = make(map[string]semver.VersionRange, len(m.Dependencies))
+		for dep, r := range m.Dependencies {
+			cm.Dependencies[dep] = r
+		}
+	}
+	return &cm
+}
+
+// A Registry provides the published versions of packages
+type Registry interface {
+	// Versions returns all versions of the package with the given name, including yanked versions, in
+	// ascending order. An empty slice is returned when the package is unknown.
+	Versions(name string) ([]semver.Version, error)
+
+	// Metadata returns a copy of the metadata of the given version of a package or an error if no such
+	// version has been published
+	Metadata(name string, v semver.Version) (*Metadata, error)
+
+	// DistTags returns the dist-tags of a package, e.g. "latest" or "next", mapped to the versions they
+	// point to. An empty map is returned when the package is unknown or has no tags.
+	DistTags(name string) (map[string]semver.Version, error)
+}
+
+// A MutableRegistry is a Registry where versions can be published, yanked, and tagged
+type MutableRegistry interface {
+	Registry
+
+	// Publish adds a copy of the metadata of a version of a package. An error is returned if the metadata
+	// or its version is nil, if the version already exists, or if a dependency has a nil version range.
+	Publish(name string, m *Metadata) error
+
+	// SetYanked changes the yanked status of a published version
+	SetYanked(name string, v semver.Version, yanked bool) error
+
+	// SetDistTag makes the dist-tag of a package point to the given published version
+	SetDistTag(name, tag string, v semver.Version) error
+}
+
+// Available returns the versions of a package that are neither yanked nor deprecated in ascending order
+func Available(r Registry, name string) ([]semver.Version, error) {
+	set, err := VersionSet(r, name)
+	if err != nil {
+		return nil, err
+	}
+	return set.Available(), nil
+}
+
+// VersionSet returns the versions of a package as a semver.VersionSet where yanked and deprecated versions
+// have the corresponding status. The reason of a deprecated version is its deprecation message. A version
+// that is both yanked and deprecated has the status semver.Yanked.
+func VersionSet(r Registry, name string) (semver.VersionSet, error) {
+	vs, err := r.Versions(name)
+	if err != nil {
+		return nil, err
+	}
+	set := semver.NewVersionSet(vs...)
+	for _, v := range vs {
+		m, err := r.Metadata(name, v)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case m.Yanked:
+			err = set.SetStatus(v, semver.Yanked, ``)
+		case m.Deprecated != ``:
+			err = set.SetStatus(v, semver.Deprecated, m.Deprecated)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return set, nil
+}
+
+// MaxSatisfying returns the highest version of a package that is included in the given range and neither
+// yanked nor deprecated, or nil if no such version exists. A range that only includes one version, e.g.
+// "=1.2.3", selects that version regardless of its status. See semver.VersionSet.
+func MaxSatisfying(r Registry, name string, rng semver.VersionRange) (semver.Version, error) {
+	set, err := VersionSet(r, name)
+	if err != nil {
+		return nil, err
+	}
+	return set.MaxSatisfying(rng), nil
+}
+
+// Select is like MaxSatisfying but returns the locked version, even if it is yanked or deprecated, when it
+// is published and still included in the range. The locked version may be nil.
+func Select(r Registry, name string, rng semver.VersionRange, locked semver.Version) (semver.Version, error) {
+	set, err := VersionSet(r, name)
+	if err != nil {
+		return nil, err
+	}
+	return set.Select(rng, locked), nil
+}
+
+type source struct {
+	registry Registry
+	locked   map[string]semver.Version
+}
+
+// AsSource returns a resolver.PinnedSource that provides the versions of the given registry that are neither
+// yanked nor deprecated. A yanked or deprecated version is only provided when the ranges of a package
+// include no other version, e.g. "=1.2.3". See MaxSatisfying.
+func AsSource(r Registry) resolver.PinnedSource {
+	return AsLockedSource(r, nil)
+}
+
+// AsLockedSource is like AsSource but also provides the locked version of each package, even if it is
+// yanked or deprecated, as long as it is published. See Select.
+func AsLockedSource(r Registry, locked map[string]semver.Version) resolver.PinnedSource {
+	return &source{r, locked}
+}
+
+func (s *source) Versions(name string) ([]semver.Version, error) {
+	set, err := VersionSet(s.registry, name)
+	if err != nil {
+		return nil, err
+	}
+	vs := set.Available()
+	if lv, ok := s.locked[name]; ok {
+		for _, v := range set.Versions() {
+			if st, _ := set.Status(v); st != semver.Active && v.CompareTo(lv) == 0 {
+				vs = append(vs, v)
+			}
+		}
+	}
+	return vs, nil
+}
+
+func (s *source) Pinned(name string, rng semver.VersionRange) (semver.Version, error) {
+	set, err := VersionSet(s.registry, name)
+	if err != nil {
+		return nil, err
+	}
+	return set.MaxSatisfying(rng), nil
+}
+
+func (s *source) Dependencies(name string, v semver.Version) (map[string]semver.VersionRange, error) {
+	m, err := s.registry.Metadata(name, v)
+	if err != nil {
+		return nil, err
+	}
+	return m.Dependencies, nil
+}
@@ -0,0 +1,77 @@
+package registry_test
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/semver/registry"
+	"github.com/lyraproj/semver/resolver"
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleMaxSatisfying() {
+	r := registry.NewMemoryRegistry()
+	publish(r, `foo`, `1.2.0`, `1.9.0`, `1.10.0`, `2.0.0`)
+	r.SetYanked(`foo`, semver.MustParseVersion(`1.10.0`), true)
+	fmt.Println(registry.MaxSatisfying(r, `foo`, semver.MustParseVersionRange(`^1.2.0`)))
+	fmt.Println(registry.MaxSatisfying(r, `foo`, semver.MustParseVersionRange(`^3.0.0`)))
+	// Output:
+	// 1.9.0 <nil>
+	// <nil> <nil>
+}
+
+func ExampleSelect() {
+	r := registry.NewMemoryRegistry()
+	publish(r, `foo`, `1.0.0`, `1.1.0`)
+	r.Publish(`foo`, &registry.Metadata{Version: semver.MustParseVersion(`1.2.0`), Deprecated: `use 1.1.0`})
+	r.SetYanked(`foo`, semver.MustParseVersion(`1.1.0`), true)
+	set, _ := registry.VersionSet(r, `foo`)
+	fmt.Println(set.Status(semver.MustParseVersion(`1.2.0`)))
+
+	rng := semver.MustParseVersionRange(`^1.0.0`)
+	fmt.Println(registry.MaxSatisfying(r, `foo`, rng))
+	fmt.Println(registry.MaxSatisfying(r, `foo`, semver.MustParseVersionRange(`=1.1.0`)))
+	fmt.Println(registry.Select(r, `foo`, rng, semver.MustParseVersion(`1.1.0`)))
+	fmt.Println(registry.Select(r, `foo`, rng, nil))
+	// Output:
+	// deprecated use 1.1.0
+	// 1.0.0 <nil>
+	// 1.1.0 <nil>
+	// 1.1.0 <nil>
+	// 1.0.0 <nil>
+}
+
+func ExampleAsSource() {
+	r := registry.NewMemoryRegistry()
+	r.Publish(`app`, &registry.Metadata{
+		Version:      semver.MustParseVersion(`1.0.0`),
+		Dependencies: map[string]semver.VersionRange{`foo`: semver.MustParseVersionRange(`^1.0.0`)}})
+	publish(r, `foo`, `1.0.0`, `1.1.0`)
+	r.SetYanked(`foo`, semver.MustParseVersion(`1.1.0`), true)
+	solution, err := resolver.Resolve(registry.AsSource(r), `app`, semver.MustParseVersion(`1.0.0`))
+	fmt.Println(solution[`foo`], err)
+	// Output: 1.0.0 <nil>
+}
+
+func ExampleAsLockedSource() {
+	r := registry.NewMemoryRegistry()
+	r.Publish(`app`, &registry.Metadata{
+		Version: semver.MustParseVersion(`1.0.0`),
+		Dependencies: map[string]semver.VersionRange{
+			`foo`: semver.MustParseVersionRange(`^1.0.0`),
+			`bar`: semver.MustParseVersionRange(`=2.0.0`)}})
+	publish(r, `foo`, `1.0.0`, `1.1.0`)
+	r.Publish(`foo`, &registry.Metadata{Version: semver.MustParseVersion(`1.2.0`), Deprecated: `use 1.1.0`})
+	r.SetYanked(`foo`, semver.MustParseVersion(`1.1.0`), true)
+	publish(r, `bar`, `2.0.0`, `2.1.0`)
+	r.SetYanked(`bar`, semver.MustParseVersion(`2.0.0`), true)
+
+	app := semver.MustParseVersion(`1.0.0`)
+	solution, err := resolver.Resolve(registry.AsSource(r), `app`, app)
+	fmt.Println(solution[`foo`], solution[`bar`], err)
+	locked := map[string]semver.Version{`foo`: semver.MustParseVersion(`1.1.0`)}
+	solution, err = resolver.Resolve(registry.AsLockedSource(r, locked), `app`, app)
+	fmt.Println(solution[`foo`], solution[`bar`], err)
+	// Output:
+	// 1.0.0 2.0.0 <nil>
+	// 1.1.0 2.0.0 <nil>
+}
@@ -0,0 +1,168 @@
+package resolver
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// An explainer writes the derivation tree of a failed resolution as a sequence of lines where each line
+// explains one derived incompatibility in terms of its two causes. Lines that are referenced more than
+// once are numbered. The format follows the error reporting described in the PubGrub documentation.
+type explainer struct {
+	root        *incompatibility
+	lines       []explanationLine
+	lineNumbers map[*incompatibility]int
+	derivations map[*incompatibility]int
+}
+
+type explanationLine struct {
+	message string
+	number  int
+}
+
+func explain(root *incompatibility) string {
+	e := &explainer{root: root, lineNumbers: make(map[*incompatibility]int), derivations: make(map[*incompatibility]int)}
+	e.countDerivations(root)
+	if root.isDerived() {
+		e.visit(root, false)
+	} else {
+		e.write(root, fmt.Sprintf(`Because %s, version solving failed.`, root), false)
+	}
+
+	bld := bytes.NewBufferString(``)
+	padding := 0
+	if len(e.lineNumbers) > 0 {
+		padding = len(fmt.Sprintf(`(%d) `, len(e.lineNumbers)))
+	}
+	for idx, l := range e.lines {
+		if idx > 0 {
+			bld.WriteByte('\n')
+		}
+		if l.message == `` {
+			continue
+		}
+		prefix := ``
+		if l.number > 0 {
+			prefix = fmt.Sprintf(`(%d) `, l.number)
+		}
+		bld.WriteString(prefix)
+		bld.WriteString(strings.Repeat(` `, padding-len(prefix)))
+		bld.WriteString(l.message)
+	}
+	return bld.String()
+}
+
+func (e *explainer) countDerivations(ic *incompatibility) {
+	if _, ok := e.derivations[ic]; ok {
+		e.derivations[ic]++
+		return
+	}
+	e.derivations[ic] = 1
+	if ic.isDerived() {
+		e.countDerivations(ic.cause1)
+		e.countDerivations(ic.cause2)
+	}
+}
+
+func (e *explainer) visit(ic *incompatibility, conclusion bool) {
+	numbered := conclusion || e.derivations[ic] > 1
+	conjunction := `And`
+	if conclusion || ic == e.root {
+		conjunction = `So,`
+	}
+	s := e.str(ic)
+	c1, c2 := ic.cause1, ic.cause2
+
+	switch {
+	case c1.isDerived() && c2.isDerived():
+		l1, ok1 := e.lineNumbers[c1]
+		l2, ok2 := e.lineNumbers[c2]
+		switch {
+		case ok1 && ok2:
+			e.write(ic, fmt.Sprintf(`Because %s (%d) and %s (%d), %s.`, c1, l1, c2, l2, s), numbered)
+		case ok1 || ok2:
+			withLine, withoutLine, line := c1, c2, l1
+			if ok2 {
+				withLine, withoutLine, line = c2, c1, l2
+			}
+			e.visit(withoutLine, false)
+			e.write(ic, fmt.Sprintf(`%s because %s (%d), %s.`, conjunction, withLine, line, s), numbered)
+		default:
+			single1, single2 := isSingleLine(c1), isSingleLine(c2)
+			if single1 || single2 {
+				first, second := c2, c1
+				if single2 {
+					first, second = c1, c2
+				}
+				e.visit(first, false)
+				e.visit(second, false)
+				e.write(ic, fmt.Sprintf(`Thus, %s.`, s), numbered)
+			} else {
+				e.visit(c1, true)
+				e.lines = append(e.lines, explanationLine{})
+				e.visit(c2, false)
+				e.write(ic, fmt.Sprintf(`%s because %s (%d), %s.`, conjunction, c1, e.lineNumbers[c1], s), numbered)
+			}
+		}
+	case c1.isDerived() || c2.isDerived():
+		derived, ext := c1, c2
+		if c2.isDerived() {
+			derived, ext = c2, c1
+		}
+		if line, ok := e.lineNumbers[derived]; ok {
+			e.write(ic, fmt.Sprintf(`Because %s and %s (%d), %s.`, ext, derived, line, s), numbered)
+		} else if e.isCollapsible(derived) {
+			dc1, dc2 := derived.cause1, derived.cause2
+			collapsedDerived, collapsedExt := dc1, dc2
+			if dc2.isDerived() {
+				collapsedDerived, collapsedExt = dc2, dc1
+			}
+			e.visit(collapsedDerived, false)
+			e.write(ic, fmt.Sprintf(`%s because %s and %s, %s.`, conjunction, collapsedExt, ext, s), numbered)
+		} else {
+			e.visit(derived, false)
+			e.write(ic, fmt.Sprintf(`%s because %s, %s.`, conjunction, ext, s), numbered)
+		}
+	default:
+		e.write(ic, fmt.Sprintf(`Because %s and %s, %s.`, c1, c2, s), numbered)
+	}
+}
+
+// isCollapsible returns true if the explanation of the given incompatibility can be merged into the
+// explanation of the incompatibility that it causes
+func (e *explainer) isCollapsible(ic *incompatibility) bool {
+	if e.derivations[ic] > 1 {
+		return false
+	}
+	c1, c2 := ic.cause1, ic.cause2
+	if c1.isDerived() == c2.isDerived() {
+		return false
+	}
+	complex := c1
+	if c2.isDerived() {
+		complex = c2
+	}
+	_, numbered := e.lineNumbers[complex]
+	return !numbered
+}
+
+func isSingleLine(ic *incompatibility) bool {
+	return !ic.cause1.isDerived() && !ic.cause2.isDerived()
+}
+
+func (e *explainer) str(ic *incompatibility) string {
+	if ic == e.root {
+		return `version solving failed`
+	}
+	return ic.String()
+}
+
+func (e *explainer) write(ic *incompatibility, message string, numbered bool) {
+	l := explanationLine{message: message}
+	if numbered {
+		l.number = len(e.lineNumbers) + 1
+		e.lineNumbers[ic] = l.number
+	}
+	e.lines = append(e.lines, l)
+}
@@ -0,0 +1,135 @@
+package resolver
+
+import (
+	"sort"
+	"strings"
+)
+
+// cause tells why an incompatibility was added
+type cause int
+
+const (
+	// rootCause is the cause of the incompatibility that requires the root package to be selected
+	rootCause cause = iota
+
+	// dependencyCause means that a package version depends on another package
+	dependencyCause
+
+	// noVersionsCause means that the source has no versions that match a term
+	noVersionsCause
+
+	// conflictCause means that the incompatibility was derived from two other incompatibilities
+	conflictCause
+)
+
+// An incompatibility is a set of terms that must not all be true at the same time
+type incompatibility struct {
+	terms  []*term
+	cause  cause
+	cause1 *incompatibility
+	cause2 *incompatibility
+}
+
+// newIncompatibility creates an incompatibility where terms for the same package have been merged
+func newIncompatibility(terms []*term, c cause, root string, cause1, cause2 *incompatibility) *incompatibility {
+	if len(terms) != 1 && c == conflictCause {
+		// The root package is always selected so a positive root term is always satisfied
+		kept := make([]*term, 0, len(terms))
+		for _, t := range terms {
+			if !(t.positive && t.pkg == root) {
+				kept = append(kept, t)
+			}
+		}
+		terms = kept
+	}
+
+	merged := make([]*term, 0, len(terms))
+	byPkg := make(map[string]int, len(terms))
+	for _, t := range terms {
+		if idx, ok := byPkg[t.pkg]; ok {
+			if is := merged[idx].intersect(t); is != nil {
+				merged[idx] = is
+			}
+			continue
+		}
+		byPkg[t.pkg] = len(merged)
+		merged = append(merged, t)
+	}
+	return &incompatibility{merged, c, cause1, cause2}
+}
+
+// isFailure returns true if the incompatibility means that no solution exists
+func (ic *incompatibility) isFailure(root string) bool {
+	return len(ic.terms) == 0 || len(ic.terms) == 1 && ic.terms[0].positive && ic.terms[0].pkg == root
+}
+
+func (ic *incompatibility) isDerived() bool {
+	return ic.cause == conflictCause
+}
+
+func (ic *incompatibility) String() string {
+	switch ic.cause {
+	case dependencyCause:
+		return terseEvery(ic.terms[0]) + ` depends on ` + terse(ic.terms[1].inverse())
+	case noVersionsCause:
+		t := ic.terms[0]
+		if t.rng.Complement() == nil {
+			return `no versions of ` + t.pkg + ` are available`
+		}
+		return `no versions of ` + t.pkg + ` match ` + rangeString(t.rng)
+	case rootCause:
+		return terse(ic.terms[0].inverse()) + ` is required`
+	}
+
+	switch len(ic.terms) {
+	case 0:
+		return `version solving failed`
+	case 1:
+		t := ic.terms[0]
+		if t.positive {
+			return terseEvery(t) + ` is forbidden`
+		}
+		return terse(t.inverse()) + ` is required`
+	case 2:
+		a, b := ic.terms[0], ic.terms[1]
+		switch {
+		case a.positive && b.positive:
+			return terseEvery(a) + ` is incompatible with ` + terse(b)
+		case a.positive:
+			return terseEvery(a) + ` requires ` + terse(b.inverse())
+		case b.positive:
+			return terseEvery(b) + ` requires ` + terse(a.inverse())
+		}
+	}
+
+	var positive, negative []string
+	for _, t := range ic.terms {
+		if t.positive {
+			positive = append(positive, terse(t))
+		} else {
+			negative = append(negative, terse(t.inverse()))
+		}
+	}
+	sort.Strings(positive)
+	sort.Strings(negative)
+	if len(positive) == 0 {
+		return `one of ` + strings.Join(negative, ` or `) + ` is required`
+	}
+	if len(negative) == 0 {
+		return strings.Join(positive, ` and `) + ` are incompatible`
+	}
+	return strings.Join(positive, ` and `) + ` requires ` + strings.Join(negative, ` or `)
+}
+
+// terse returns the string form of a positive term
+func terse(t *term) string {
+	return t.pkg + ` ` + rangeString(t.rng)
+}
+
+// terseEvery is like terse but writes "every version of" instead of the range when the range is "*"
+func terseEvery(t *term) string {
+	if t.rng.Complement() == nil {
+		return `every version of ` + t.pkg
+	}
+	return terse(t)
+}
@@ -0,0 +1,323 @@
+// Package resolver selects package versions that satisfy a graph of version range dependencies using
+// the PubGrub algorithm. See https://github.com/dart-lang/pub/blob/master/doc/solver.md for a description
+// of the algorithm.
+package resolver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+// A Source provides the packages that Resolve chooses from
+type Source interface {
+	// Versions returns the available versions of the given package. An empty slice is returned when the
+	// package is unknown.
+	Versions(pkg string) ([]semver.Version, error)
+
+	// Dependencies returns the dependencies of the given version of a package as a map from package name
+	// to version range
+	Dependencies(pkg string, v semver.Version) (map[string]semver.VersionRange, error)
+}
+
+// A PinnedSource is a Source that leaves some versions out of Versions, e.g. yanked or deprecated versions,
+// but still provides such a version when a range includes only that version
+type PinnedSource interface {
+	Source
+
+	// Pinned returns the version that the given range pins, i.e. the only version that it includes, or nil
+	// if there is no such version. It is only called when the range includes none of the versions that
+	// Versions returns.
+	Pinned(pkg string, rng semver.VersionRange) (semver.Version, error)
+}
+
+// A NoSolutionError is returned by Resolve when no selection of versions satisfies all dependencies. Its
+// message is an explanation of why, derived from the chain of incompatibilities that led to the failure.
+type NoSolutionError struct {
+	incompatibility *incompatibility
+}
+
+func (e *NoSolutionError) Error() string {
+	return explain(e.incompatibility)
+}
+
+type solver struct {
+	source            Source
+	root              string
+	rootVersion       semver.Version
+	solution          *partialSolution
+	incompatibilities map[string][]*incompatibility
+	versions          map[string][]semver.Version
+}
+
+// Resolve selects a version of each package that is reachable from the given root package and version
+// so that all dependencies are satisfied. The highest matching version of a package is preferred. The
+// result includes the root package.
+//
+// Pre-releases are only selected when a dependency range explicitly includes them, i.e. according to
+// VersionRange.Includes. When the source is a PinnedSource, a version that its Versions leaves out is
+// selected when the ranges that apply to its package only include that version.
+func Resolve(src Source, root string, v semver.Version) (map[string]semver.Version, error) {
+	s := &solver{
+		source:            src,
+		root:              root,
+		rootVersion:       v,
+		solution:          newPartialSolution(),
+		incompatibilities: make(map[string][]*incompatibility),
+		versions:          make(map[string][]semver.Version)}
+	s.addIncompatibility(newIncompatibility([]*term{{root, semver.ExactVersionRange(v), false}}, rootCause, root, nil, nil))
+
+	next := root
+	for {
+		if err := s.propagate(next); err != nil {
+			return nil, err
+		}
+		var err error
+		var done bool
+		if next, done, err = s.choosePackageVersion(); err != nil {
+			return nil, err
+		}
+		if done {
+			result := make(map[string]semver.Version, len(s.solution.decisions))
+			for pkg, dv := range s.solution.decisions {
+				result[pkg] = dv
+			}
+			return result, nil
+		}
+	}
+}
+
+func (s *solver) addIncompatibility(ic *incompatibility) {
+	for _, t := range ic.terms {
+		s.incompatibilities[t.pkg] = append(s.incompatibilities[t.pkg], ic)
+	}
+}
+
+// propagate performs unit propagation starting with the given package
+func (s *solver) propagate(pkg string) error {
+	changed := []string{pkg}
+	for len(changed) > 0 {
+		pkg = changed[len(changed)-1]
+		changed = changed[:len(changed)-1]
+		ics := s.incompatibilities[pkg]
+		for idx := len(ics) - 1; idx >= 0; idx-- {
+			ic := ics[idx]
+			t, conflict := s.propagateIncompatibility(ic)
+			if conflict {
+				rootCause, err := s.resolveConflict(ic)
+				if err != nil {
+					return err
+				}
+				// The root cause is now almost satisfied so it will derive a new term
+				changed = changed[:0]
+				if t, _ = s.propagateIncompatibility(rootCause); t != nil {
+					changed = append(changed, t.pkg)
+				}
+				break
+			}
+			if t != nil {
+				changed = append(changed, t.pkg)
+			}
+		}
+	}
+	return nil
+}
+
+// propagateIncompatibility derives the inverse of the one unsatisfied term of the given incompatibility
+// and returns it. Nothing is derived when more than one term is unsatisfied or when one term is
+// contradicted. The returned boolean is true when all terms are satisfied.
+func (s *solver) propagateIncompatibility(ic *incompatibility) (*term, bool) {
+	var unsatisfied *term
+	for _, t := range ic.terms {
+		switch s.solution.relation(t) {
+		case disjoint:
+			return nil, false
+		case overlapping:
+			if unsatisfied != nil {
+				return nil, false
+			}
+			unsatisfied = t
+		}
+	}
+	if unsatisfied == nil {
+		return nil, true
+	}
+	s.solution.derive(unsatisfied.inverse(), ic)
+	return unsatisfied, false
+}
+
+// resolveConflict backtracks the partial solution until the given incompatibility, or one derived from
+// it, is no longer satisfied, and returns that incompatibility
+func (s *solver) resolveConflict(ic *incompatibility) (*incompatibility, error) {
+	created := false
+	for !ic.isFailure(s.root) {
+		var recentTerm, difference *term
+		var recent *assignment
+		previousLevel := 1
+		for _, t := range ic.terms {
+			satisfier := s.solution.satisfier(t)
+			if recent == nil || satisfier.index > recent.index {
+				if recent != nil && recent.decisionLevel > previousLevel {
+					previousLevel = recent.decisionLevel
+				}
+				recentTerm = t
+				recent = satisfier
+				difference = nil
+			} else if satisfier.decisionLevel > previousLevel {
+				previousLevel = satisfier.decisionLevel
+			}
+			if recentTerm == t {
+				// The satisfier may only satisfy the term together with earlier assignments
+				if difference = recent.term.difference(recentTerm); difference != nil {
+					if ds := s.solution.satisfier(difference.inverse()); ds != nil && ds.decisionLevel > previousLevel {
+						previousLevel = ds.decisionLevel
+					}
+				}
+			}
+		}
+
+		if previousLevel < recent.decisionLevel || recent.isDecision() {
+			s.solution.backtrack(previousLevel)
+			if created {
+				s.addIncompatibility(ic)
+			}
+			return ic, nil
+		}
+
+		terms := make([]*term, 0, len(ic.terms)+len(recent.cause.terms))
+		for _, t := range ic.terms {
+			if t != recentTerm {
+				terms = append(terms, t)
+			}
+		}
+		for _, t := range recent.cause.terms {
+			if t.pkg != recent.pkg {
+				terms = append(terms, t)
+			}
+		}
+		if difference != nil {
+			terms = append(terms, difference.inverse())
+		}
+		ic = newIncompatibility(terms, conflictCause, s.root, ic, recent.cause)
+		created = true
+	}
+	return nil, &NoSolutionError{ic}
+}
+
+// choosePackageVersion decides on a version for the package with the fewest matching versions among the
+// packages that have a positive term but no decision. It returns the name of the package or true when
+// all such packages have been decided.
+func (s *solver) choosePackageVersion() (string, bool, error) {
+	unsatisfied := s.solution.unsatisfied()
+	if len(unsatisfied) == 0 {
+		return ``, true, nil
+	}
+
+	var chosen *term
+	var candidates []semver.Version
+	for _, t := range unsatisfied {
+		vs, err := s.matchingVersions(t)
+		if err != nil {
+			return ``, false, err
+		}
+		if chosen == nil || len(vs) < len(candidates) || len(vs) == len(candidates) && t.pkg < chosen.pkg {
+			chosen = t
+			candidates = vs
+		}
+	}
+
+	if len(candidates) == 0 {
+		s.addIncompatibility(newIncompatibility([]*term{chosen}, noVersionsCause, s.root, nil, nil))
+		return chosen.pkg, false, nil
+	}
+
+	v := candidates[0]
+	deps, err := s.source.Dependencies(chosen.pkg, v)
+	if err != nil {
+		return ``, false, err
+	}
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	conflict := false
+	depender := &term{chosen.pkg, s.dependerRange(chosen.pkg, v), true}
+	for _, name := range names {
+		if name == chosen.pkg {
+			return ``, false, fmt.Errorf(`the package '%s' %s depends on itself`, chosen.pkg, v)
+		}
+		ic := newIncompatibility([]*term{depender, {name, deps[name], false}}, dependencyCause, s.root, nil, nil)
+		s.addIncompatibility(ic)
+		// The decision would immediately satisfy the incompatibility if the dependency is already excluded
+		conflict = conflict || s.solution.satisfies(ic.terms[1])
+	}
+	if !conflict {
+		s.solution.decide(chosen.pkg, v)
+	}
+	return chosen.pkg, false, nil
+}
+
+// dependerRange returns the range of versions that a dependency incompatibility for the given version
+// applies to. The range extends to the next available version so that explanations can refer to every
+// version of a package that only has one version, or to versions that no longer exist.
+func (s *solver) dependerRange(pkg string, v semver.Version) semver.VersionRange {
+	if pkg == s.root {
+		return semver.ExactVersionRange(v)
+	}
+	all := s.versions[pkg]
+	idx := sort.Search(len(all), func(i int) bool { return all[i].CompareTo(v) >= 0 })
+	if idx == len(all) || !all[idx].Equals(v) {
+		// A version provided by PinnedSource.Pinned
+		return semver.ExactVersionRange(v)
+	}
+	var lower, upper string
+	if idx > 0 {
+		lower = `>=` + v.String()
+	}
+	if idx+1 < len(all) {
+		upper = `<` + all[idx+1].String()
+	}
+	str := strings.TrimSpace(lower + ` ` + upper)
+	if str == `` {
+		str = `*`
+	}
+	return semver.MustParseVersionRange(str)
+}
+
+// matchingVersions returns the versions of the package of the given term that the term includes,
+// highest first
+func (s *solver) matchingVersions(t *term) ([]semver.Version, error) {
+	if t.pkg == s.root {
+		return []semver.Version{s.rootVersion}, nil
+	}
+	all, ok := s.versions[t.pkg]
+	if !ok {
+		var err error
+		if all, err = s.source.Versions(t.pkg); err != nil {
+			return nil, err
+		}
+		all = append([]semver.Version{}, all...)
+		semver.SortVersions(all)
+		s.versions[t.pkg] = all
+	}
+	result := make([]semver.Version, 0, len(all))
+	for idx := len(all) - 1; idx >= 0; idx-- {
+		if t.rng.Includes(all[idx]) {
+			result = append(result, all[idx])
+		}
+	}
+	if ps, ok := s.source.(PinnedSource); ok && len(result) == 0 {
+		v, err := ps.Pinned(t.pkg, t.rng)
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
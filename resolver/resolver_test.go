@@ -0,0 +1,101 @@
+package resolver_test
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/lyraproj/semver/resolver"
+	"github.com/lyraproj/semver/semver"
+)
+
+// testSource maps package names to versions to dependencies
+type testSource map[string]map[string]map[string]string
+
+func (s testSource) Versions(pkg string) ([]semver.Version, error) {
+	vs := make([]semver.Version, 0, len(s[pkg]))
+	for v := range s[pkg] {
+		vs = append(vs, semver.MustParseVersion(v))
+	}
+	return vs, nil
+}
+
+func (s testSource) Dependencies(pkg string, v semver.Version) (map[string]semver.VersionRange, error) {
+	deps := make(map[string]semver.VersionRange)
+	for name, r := range s[pkg][v.String()] {
+		deps[name] = semver.MustParseVersionRange(r)
+	}
+	return deps, nil
+}
+
+func printSolution(solution map[string]semver.Version, err error) {
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	names := make([]string, 0, len(solution))
+	for name := range solution {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Println(name, solution[name])
+	}
+}
+
+func ExampleResolve() {
+	src := testSource{
+		`app`: {`1.0.0`: {`foo`: `^1.0.0`, `bar`: `^1.0.0`}},
+		`foo`: {`1.0.0`: {}, `1.1.0`: {`baz`: `>=1.0.0 <1.2.0`}, `2.0.0`: {}},
+		`bar`: {`1.0.0`: {`baz`: `^1.0.0`}, `1.5.0-beta.1`: {}},
+		`baz`: {`1.0.0`: {}, `1.1.3`: {}, `1.2.0`: {}},
+	}
+	printSolution(resolver.Resolve(src, `app`, semver.MustParseVersion(`1.0.0`)))
+	// Output:
+	// app 1.0.0
+	// bar 1.0.0
+	// baz 1.1.3
+	// foo 1.1.0
+}
+
+func ExampleResolve_backtracking() {
+	src := testSource{
+		`app`: {`1.0.0`: {`foo`: `>=1.0.0`}},
+		`foo`: {`1.0.0`: {}, `2.0.0`: {`bar`: `^1.0.0`}},
+		`bar`: {`1.0.0`: {`foo`: `^1.0.0`}},
+	}
+	printSolution(resolver.Resolve(src, `app`, semver.MustParseVersion(`1.0.0`)))
+	// Output:
+	// app 1.0.0
+	// foo 1.0.0
+}
+
+func ExampleNoSolutionError() {
+	src := testSource{
+		`app`: {`1.0.0`: {`foo`: `^1.0.0`, `baz`: `^1.0.0`}},
+		`foo`: {`1.0.0`: {`bar`: `^2.0.0`}},
+		`bar`: {`2.0.0`: {`baz`: `^3.0.0`}},
+		`baz`: {`1.0.0`: {}, `3.0.0`: {}},
+	}
+	printSolution(resolver.Resolve(src, `app`, semver.MustParseVersion(`1.0.0`)))
+	// Output:
+	// Because every version of foo depends on bar >=2.0.0 <3.0.0 and every version of bar depends on baz >=3.0.0 <4.0.0, every version of foo requires baz >=3.0.0 <4.0.0.
+	// So, because app 1.0.0 depends on baz >=1.0.0 <2.0.0 and app 1.0.0 depends on foo >=1.0.0 <2.0.0, version solving failed.
+}
+
+func ExampleNoSolutionError_noVersions() {
+	src := testSource{
+		`app`: {`1.0.0`: {`foo`: `^1.0.0`}},
+		`foo`: {`1.0.0`: {`bar`: `^2.0.0`}, `1.1.0`: {`bar`: `^1.0.0`}},
+		`bar`: {`1.0.0`: {}},
+	}
+	printSolution(resolver.Resolve(src, `app`, semver.MustParseVersion(`1.0.0`)))
+	src[`bar`] = nil
+	printSolution(resolver.Resolve(src, `app`, semver.MustParseVersion(`1.0.0`)))
+	// Output:
+	// app 1.0.0
+	// bar 1.0.0
+	// foo 1.1.0
+	// Because foo <1.1.0 depends on bar >=2.0.0 <3.0.0 and no versions of bar match >=2.0.0 <3.0.0, foo <1.1.0 is forbidden.
+	// And because foo >=1.1.0 depends on bar >=1.0.0 <2.0.0, every version of foo requires bar >=1.0.0 <2.0.0.
+	// So, because no versions of bar match >=1.0.0 <2.0.0 and app 1.0.0 depends on foo >=1.0.0 <2.0.0, version solving failed.
+}
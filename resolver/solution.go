@@ -0,0 +1,135 @@
+package resolver
+
+import (
+	"github.com/lyraproj/semver/semver"
+)
+
+// An assignment is a term in the partial solution. It is either a decision, i.e. the selection of a
+// version, or a term derived from an incompatibility.
+type assignment struct {
+	*term
+	decisionLevel int
+	index         int
+	cause         *incompatibility
+}
+
+func (a *assignment) isDecision() bool {
+	return a.cause == nil
+}
+
+// A partialSolution is the list of assignments made so far along with the accumulated terms per package
+type partialSolution struct {
+	assignments   []*assignment
+	decisions     map[string]semver.Version
+	positive      map[string]*term
+	negative      map[string]*term
+	decisionLevel int
+}
+
+func newPartialSolution() *partialSolution {
+	return &partialSolution{
+		decisions: make(map[string]semver.Version),
+		positive:  make(map[string]*term),
+		negative:  make(map[string]*term)}
+}
+
+// decide adds the selection of the given version as a new decision
+func (ps *partialSolution) decide(pkg string, v semver.Version) {
+	ps.decisionLevel++
+	ps.decisions[pkg] = v
+	ps.assign(&term{pkg, semver.ExactVersionRange(v), true}, nil)
+}
+
+// derive adds a term derived from the given incompatibility
+func (ps *partialSolution) derive(t *term, cause *incompatibility) {
+	ps.assign(t, cause)
+}
+
+func (ps *partialSolution) assign(t *term, cause *incompatibility) {
+	a := &assignment{t, ps.decisionLevel, len(ps.assignments), cause}
+	ps.assignments = append(ps.assignments, a)
+	ps.register(a.term)
+}
+
+// register accumulates the given term into the terms of its package. A term that no selection can satisfy
+// is registered as a positive term with an empty range since intersect returns nil for such a term.
+func (ps *partialSolution) register(t *term) {
+	pkg := t.pkg
+	if old, ok := ps.positive[pkg]; ok {
+		t = old.intersect(t)
+	} else if old, ok := ps.negative[pkg]; ok {
+		t = t.intersect(old)
+	}
+	if t == nil {
+		t = &term{pkg, semver.MatchNone, true}
+	}
+	if t.positive {
+		delete(ps.negative, t.pkg)
+		ps.positive[t.pkg] = t
+	} else {
+		ps.negative[t.pkg] = t
+	}
+}
+
+// backtrack removes all assignments made after the given decision level
+func (ps *partialSolution) backtrack(level int) {
+	for len(ps.assignments) > 0 && ps.assignments[len(ps.assignments)-1].decisionLevel > level {
+		a := ps.assignments[len(ps.assignments)-1]
+		ps.assignments = ps.assignments[:len(ps.assignments)-1]
+		if a.isDecision() {
+			delete(ps.decisions, a.pkg)
+		}
+	}
+	ps.decisionLevel = level
+	ps.positive = make(map[string]*term)
+	ps.negative = make(map[string]*term)
+	for _, a := range ps.assignments {
+		ps.register(a.term)
+	}
+}
+
+// relation returns the relation between the accumulated term of the package and the given term
+func (ps *partialSolution) relation(t *term) relation {
+	if p, ok := ps.positive[t.pkg]; ok {
+		return p.relation(t)
+	}
+	if n, ok := ps.negative[t.pkg]; ok {
+		return n.relation(t)
+	}
+	return overlapping
+}
+
+func (ps *partialSolution) satisfies(t *term) bool {
+	return ps.relation(t) == subset
+}
+
+// satisfier returns the earliest assignment that, together with all earlier assignments, satisfies the
+// given term
+func (ps *partialSolution) satisfier(t *term) *assignment {
+	var acc *term
+	for _, a := range ps.assignments {
+		if a.pkg != t.pkg {
+			continue
+		}
+		if acc == nil {
+			acc = a.term
+		} else {
+			acc = acc.intersect(a.term)
+		}
+		if acc == nil || acc.satisfies(t) {
+			return a
+		}
+	}
+	return nil
+}
+
+// unsatisfied returns the positive terms of the packages that have no decision
+func (ps *partialSolution) unsatisfied() []*term {
+	result := make([]*term, 0)
+	for pkg, t := range ps.positive {
+		if _, ok := ps.decisions[pkg]; !ok {
+			result = append(result, t)
+		}
+	}
+	return result
+}
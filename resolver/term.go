@@ -0,0 +1,120 @@
+package resolver
+
+import (
+	"github.com/lyraproj/semver/semver"
+)
+
+// A term is a statement about a package that is either true or false for a given selection of package
+// versions. A positive term "foo ^1.0.0" is true when a version of foo in ^1.0.0 is selected. A negative
+// term "not foo ^1.0.0" is true when no version of foo is selected or when the selected version is not
+// in ^1.0.0.
+type term struct {
+	pkg      string
+	rng      semver.VersionRange
+	positive bool
+}
+
+// relation describes how the set of selections that satisfy one term relates to that of another term
+type relation int
+
+const (
+	disjoint relation = iota
+	overlapping
+	subset
+)
+
+func (t *term) inverse() *term {
+	return &term{t.pkg, t.rng, !t.positive}
+}
+
+// intersect returns a term that is satisfied when both the receiver and the given term are satisfied, or
+// nil if no selection satisfies both terms
+func (t *term) intersect(o *term) *term {
+	switch {
+	case t.positive && o.positive:
+		return nonEmptyTerm(t.pkg, t.rng.Intersection(o.rng))
+	case t.positive:
+		return nonEmptyTerm(t.pkg, t.rng.Difference(o.rng))
+	case o.positive:
+		return nonEmptyTerm(t.pkg, o.rng.Difference(t.rng))
+	default:
+		return &term{t.pkg, t.rng.Merge(o.rng), false}
+	}
+}
+
+// difference returns a term that is satisfied when the receiver is satisfied and the given term is not,
+// or nil if no such selection exists
+func (t *term) difference(o *term) *term {
+	return t.intersect(o.inverse())
+}
+
+// relation returns the relation between the receiver and the given term
+func (t *term) relation(o *term) relation {
+	if o.positive {
+		if t.positive {
+			switch {
+			case !allowsAny(o.rng, t.rng):
+				return disjoint
+			case allowsAll(o.rng, t.rng):
+				return subset
+			}
+			return overlapping
+		}
+		if allowsAll(t.rng, o.rng) {
+			return disjoint
+		}
+		return overlapping
+	}
+	if t.positive {
+		switch {
+		case !allowsAny(o.rng, t.rng):
+			return subset
+		case allowsAll(o.rng, t.rng):
+			return disjoint
+		}
+		return overlapping
+	}
+	if allowsAll(t.rng, o.rng) {
+		return subset
+	}
+	return overlapping
+}
+
+// satisfies returns true if the receiver can only be satisfied when the given term is satisfied
+func (t *term) satisfies(o *term) bool {
+	return t.relation(o) == subset
+}
+
+func (t *term) String() string {
+	s := t.pkg + ` ` + rangeString(t.rng)
+	if !t.positive {
+		return `not ` + s
+	}
+	return s
+}
+
+func nonEmptyTerm(pkg string, rng semver.VersionRange) *term {
+	if rng == nil {
+		return nil
+	}
+	return &term{pkg, rng, true}
+}
+
+// allowsAll returns true if a includes all versions that b includes
+func allowsAll(a, b semver.VersionRange) bool {
+	return b.Difference(a) == nil
+}
+
+// allowsAny returns true if a and b have at least one version in common
+func allowsAny(a, b semver.VersionRange) bool {
+	return a.Intersection(b) != nil
+}
+
+// rangeString returns the normalized form of the range so that ranges given in dependencies and ranges
+// derived from them read the same way in explanations
+func rangeString(r semver.VersionRange) string {
+	if r.Complement() == nil {
+		return `*`
+	}
+	return r.NormalizedString()
+}
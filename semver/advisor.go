@@ -0,0 +1,78 @@
+package semver
+
+// Candidate is a version that a current version can be upgraded to
+type Candidate struct {
+	// Version is the version
+	Version Version
+
+	// Wanted is true when the version is included in the range that was given to Advise
+	Wanted bool
+}
+
+// Advice categorizes the versions that a current version can be upgraded to in the same way as
+// "npm outdated" does. A candidate is nil when no published version in its category is higher than the
+// current version.
+type Advice struct {
+	// Current is the current version
+	Current Version
+
+	// Wanted is the highest published version that is included in the range that was given to Advise,
+	// or nil if no such version exists. It may be lower than the current version.
+	Wanted Version
+
+	// Patch is the highest version with the same epoch, major, and minor number as the current version
+	Patch *Candidate
+
+	// Minor is the highest version with the same epoch and major number as the current version
+	Minor *Candidate
+
+	// Latest is the highest version overall
+	Latest *Candidate
+}
+
+// Advise returns the upgrade candidates for the current version found among the published versions. The
+// given range, which may be nil, determines the wanted version and whether each candidate is wanted.
+//
+// Pre-releases are only considered when the current version is a pre-release of the same
+// epoch, major, minor, and patch, which allows moving from one pre-release to the next or to the release
+// itself.
+func Advise(current Version, published []Version, r VersionRange) *Advice {
+	a := &Advice{Current: current}
+	var patch, minor, latest Version
+	for _, v := range published {
+		if v.CompareTo(current) <= 0 || !(v.IsStable() || v.TripletEquals(current)) {
+			continue
+		}
+		if latest == nil || v.CompareTo(latest) > 0 {
+			latest = v
+		}
+		if v.Epoch() != current.Epoch() || v.Major() != current.Major() {
+			continue
+		}
+		if minor == nil || v.CompareTo(minor) > 0 {
+			minor = v
+		}
+		if v.Minor() == current.Minor() && (patch == nil || v.CompareTo(patch) > 0) {
+			patch = v
+		}
+	}
+	if r != nil {
+		a.Wanted = MaxSatisfying(published, r)
+	}
+	a.Patch = newCandidate(patch, r)
+	a.Minor = newCandidate(minor, r)
+	a.Latest = newCandidate(latest, r)
+	return a
+}
+
+// IsOutdated returns true if a version higher than the current version has been published
+func (a *Advice) IsOutdated() bool {
+	return a.Latest != nil
+}
+
+func newCandidate(v Version, r VersionRange) *Candidate {
+	if v == nil {
+		return nil
+	}
+	return &Candidate{Version: v, Wanted: r != nil && r.Includes(v)}
+}
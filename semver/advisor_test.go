@@ -0,0 +1,47 @@
+package semver_test
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleAdvise() {
+	var published []semver.Version
+	for _, s := range []string{`1.2.0`, `1.2.3`, `1.2.5`, `1.3.0`, `1.4.1`, `2.0.0-rc.1`, `2.0.0`, `2.1.0`} {
+		published = append(published, semver.MustParseVersion(s))
+	}
+	show := func(name string, c *semver.Candidate) {
+		if c == nil {
+			fmt.Println(name, `-`)
+		} else {
+			fmt.Println(name, c.Version, c.Wanted)
+		}
+	}
+
+	a := semver.Advise(semver.MustParseVersion(`1.2.3`), published, semver.MustParseVersionRange(`~1.2.0`))
+	fmt.Println(`wanted`, a.Wanted)
+	show(`patch`, a.Patch)
+	show(`minor`, a.Minor)
+	show(`latest`, a.Latest)
+
+	a = semver.Advise(semver.MustParseVersion(`2.1.0`), published, nil)
+	fmt.Println(a.IsOutdated())
+	// Output:
+	// wanted 1.2.5
+	// patch 1.2.5 true
+	// minor 1.4.1 false
+	// latest 2.1.0 false
+	// false
+}
+
+func ExampleAdvise_preRelease() {
+	var published []semver.Version
+	for _, s := range []string{`2.0.0-rc.1`, `2.0.0-rc.2`, `2.1.0-beta.1`} {
+		published = append(published, semver.MustParseVersion(s))
+	}
+	a := semver.Advise(semver.MustParseVersion(`2.0.0-rc.1`), published, semver.MustParseVersionRange(`^2.0.0-rc.1`))
+	fmt.Println(a.Wanted, a.Latest.Version, a.Latest.Wanted)
+	// Output:
+	// 2.0.0-rc.2 2.0.0-rc.2 true
+}
@@ -0,0 +1,82 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BuildOrder compares the build metadata of two versions. Identifiers are compared from left to right where
+// sequences of digits are compared by numeric value, so "build.5" is lower than "build.12". A version without
+// build metadata is lower than a version that has it. Return zero if the build metadata is equal, a negative
+// integer if a has lower build metadata than b, and a positive integer if a has higher build metadata than b.
+//
+// Semantic Versioning does not define an order for build metadata and BuildOrder is therefore not used by
+// CompareTo.
+func BuildOrder(a, b Version) int {
+	ab := a.(*version).build
+	bb := b.(*version).build
+	top := len(ab)
+	if len(bb) < top {
+		top = len(bb)
+	}
+	for idx := 0; idx < top; idx++ {
+		if cmp := compareNatural(ab[idx].(string), bb[idx].(string)); cmp != 0 {
+			return cmp
+		}
+	}
+	return len(ab) - len(bb)
+}
+
+// CompareWithBuild compares two versions using CompareTo and uses BuildOrder when CompareTo considers
+// them equal.
+func CompareWithBuild(a, b Version) int {
+	cmp := a.CompareTo(b)
+	if cmp == 0 {
+		cmp = BuildOrder(a, b)
+	}
+	return cmp
+}
+
+// IncludesWithBuild returns true if the given version is included in the given range when build metadata
+// is taken into account. Build metadata is only considered when a version in the range has build metadata
+// and compares equal to the given version, so ">=1.2.3+build.5" includes "1.2.3+build.12" and "1.2.4"
+// but not "1.2.3+build.3", and "1.2.3+git.abc123" only includes a version with that exact build metadata.
+func IncludesWithBuild(r VersionRange, v Version) bool {
+	return r.(*versionRange).includesUsing(v, func(a, b Version) int {
+		cmp := a.CompareTo(b)
+		if cmp == 0 {
+			bound := a
+			if a == v {
+				bound = b
+			}
+			if bound.(*version).build != nil {
+				cmp = BuildOrder(a, b)
+			}
+		}
+		return cmp
+	})
+}
+
+// ParseBuildMetadata interprets the given build metadata as a sequence of key and value identifiers and
+// returns them as a map, e.g. "git.abc123.ts.20261016" becomes {"git": "abc123", "ts": "20261016"}. An
+// error is returned if the metadata has an odd number of identifiers or if a key is repeated.
+func ParseBuildMetadata(build string) (map[string]string, error) {
+	result := make(map[string]string)
+	if build == `` {
+		return result, nil
+	}
+	if !vPartsPattern.MatchString(build) {
+		return nil, fmt.Errorf(`Illegal characters in build`)
+	}
+	ids := strings.Split(build, `.`)
+	if len(ids)%2 != 0 {
+		return nil, fmt.Errorf(`the build metadata '%s' does not consist of key and value pairs`, build)
+	}
+	for idx := 0; idx < len(ids); idx += 2 {
+		if _, ok := result[ids[idx]]; ok {
+			return nil, fmt.Errorf(`the build metadata '%s' has more than one value for key '%s'`, build, ids[idx])
+		}
+		result[ids[idx]] = ids[idx+1]
+	}
+	return result, nil
+}
@@ -0,0 +1,38 @@
+package semver_test
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleBuildOrder() {
+	a := semver.MustParseVersion(`1.2.3+build.5`)
+	b := semver.MustParseVersion(`1.2.3+build.12`)
+	fmt.Println(a.CompareTo(b), semver.BuildOrder(a, b) < 0)
+	// Output:
+	// 0 true
+}
+
+func ExampleIncludesWithBuild() {
+	rng := semver.MustParseVersionRange(`>=1.2.3+build.5 <2.0.0`)
+	for _, s := range []string{`1.2.3+build.3`, `1.2.3+build.12`, `1.2.4`} {
+		v := semver.MustParseVersion(s)
+		fmt.Println(s, rng.Includes(v), semver.IncludesWithBuild(rng, v))
+	}
+	// Output:
+	// 1.2.3+build.3 true false
+	// 1.2.3+build.12 true true
+	// 1.2.4 true true
+}
+
+func ExampleParseBuildMetadata() {
+	md, err := semver.ParseBuildMetadata(semver.MustParseVersion(`1.2.3+git.abc123.ts.20261016`).Build())
+	if err == nil {
+		fmt.Println(md[`git`], md[`ts`])
+	} else {
+		fmt.Println(err)
+	}
+	// Output:
+	// abc123 20261016
+}
@@ -0,0 +1,32 @@
+package semver
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+)
+
+// VersionFromBuildInfo returns the version of the main module in the given build info. Go module
+// versions, including pseudo-versions such as "v0.0.0-20231010123456-abcdef123456", are semantic
+// versions with a "v" prefix. An error is returned when the main module has no version, which is the
+// case when the binary was built from a local checkout, i.e. when the version is "(devel)".
+func VersionFromBuildInfo(bi *debug.BuildInfo) (Version, error) {
+	if bi == nil {
+		return nil, fmt.Errorf(`no build info available`)
+	}
+	mv := bi.Main.Version
+	if mv == `` || mv == `(devel)` {
+		return nil, fmt.Errorf(`the main module '%s' has no version`, bi.Main.Path)
+	}
+	return ParseVersion(strings.TrimPrefix(mv, `v`))
+}
+
+// MainModuleVersion returns the version of the main module of the running binary. See
+// VersionFromBuildInfo.
+func MainModuleVersion() (Version, error) {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil, fmt.Errorf(`no build info available`)
+	}
+	return VersionFromBuildInfo(bi)
+}
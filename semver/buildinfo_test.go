@@ -0,0 +1,24 @@
+package semver_test
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleVersionFromBuildInfo() {
+	for _, mv := range []string{`v1.4.2`, `v0.0.0-20231010123456-abcdef123456`, `(devel)`} {
+		bi := &debug.BuildInfo{Main: debug.Module{Path: `example.com/tool`, Version: mv}}
+		v, err := semver.VersionFromBuildInfo(bi)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Println(v)
+	}
+	// Output:
+	// 1.4.2
+	// 0.0.0-20231010123456-abcdef123456
+	// the main module 'example.com/tool' has no version
+}
@@ -0,0 +1,374 @@
+package semver
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// A CalVerFormat describes the layout of a calendar version as specified by https://calver.org.
+// The format is a sequence of tokens separated by ".", "-", or "_". The following tokens are
+// recognized:
+//
+//	YYYY     - full year, e.g. 2006, 2016, 2106
+//	YY       - short year, e.g. 6, 16, 106
+//	0Y       - zero-padded year, e.g. 06, 16, 106
+//	MM       - short month, e.g. 1, 2, ... 11, 12
+//	0M       - zero-padded month, e.g. 01, 02, ... 11, 12
+//	WW       - short week of year (ISO 8601), e.g. 1, 2, 33, 52
+//	0W       - zero-padded week of year, e.g. 01, 02, 33, 52
+//	DD       - short day, e.g. 1, 2, ... 30, 31
+//	0D       - zero-padded day, e.g. 01, 02, ... 30, 31
+//	MICRO    - a number that is incremented for each release within the same period
+//	MODIFIER - an optional suffix such as "hotfix" or "rc.1". Must be the last token
+type CalVerFormat interface {
+	fmt.Stringer
+
+	// Parse parses the given string using this format. An error is returned unless the string
+	// matches the format and denotes a valid date.
+	Parse(str string) (CalVer, error)
+
+	// Today returns the first version of the period that contains the time returned by the given
+	// clock, i.e. a version where MICRO is zero and no modifier is present. The current time is
+	// used when the clock is nil.
+	Today(clock func() time.Time) CalVer
+}
+
+// A CalVer is a calendar version that conforms to a CalVerFormat
+type CalVer interface {
+	fmt.Stringer
+
+	// Bump returns the next version. The version will use the period that contains the time
+	// returned by the given clock. If that period is equal to the period of the receiver, then the
+	// MICRO number is incremented. An error is returned if the format has no MICRO token in that
+	// case or if the period of the clock precedes the period of the receiver. The current time is
+	// used when the clock is nil.
+	Bump(clock func() time.Time) (CalVer, error)
+
+	// CompareTo compares the receiver to another version. Return zero if the versions are equal,
+	// a negative integer if the receiver is less than the given version, and a positive
+	// integer if the receiver is greater than the given version.
+	//
+	// A version with a modifier is considered lower than the same version without a modifier.
+	CompareTo(other CalVer) int
+
+	// Format returns the format of this version
+	Format() CalVerFormat
+
+	// Modifier returns the modifier of this version or an empty string
+	Modifier() string
+
+	// Segments returns the numeric segments of this version in the order they appear
+	Segments() []int
+
+	// ToVersion converts this version into a semantic version. The numeric segments become the
+	// major, minor, and patch numbers and the modifier becomes the pre-release. A format with
+	// fewer than three numeric segments gets zero for the missing numbers, so "2026.10" becomes
+	// 2026.10.0. An error is returned if the format has more than three numeric segments.
+	ToVersion() (Version, error)
+}
+
+type calverFormat struct {
+	spec       string
+	tokens     []string
+	separators []string
+	pattern    *regexp.Regexp
+}
+
+type calver struct {
+	format   *calverFormat
+	segments []int
+	modifier []interface{}
+}
+
+var calverTokenPattern = regexp.MustCompile(`\A(?:YYYY|0Y|YY|0M|MM|0W|WW|0D|DD|MICRO|MODIFIER)`)
+var calverSeparatorPattern = regexp.MustCompile(`\A[._-]`)
+
+var calverTokenPatterns = map[string]string{
+	`YYYY`:  `([0-9]{4})`,
+	`YY`:    `(0|[1-9][0-9]*)`,
+	`0Y`:    `([0-9]{2,})`,
+	`MM`:    `(1[0-2]|[1-9])`,
+	`0M`:    `(0[1-9]|1[0-2])`,
+	`WW`:    `(5[0-3]|[1-4][0-9]|[1-9])`,
+	`0W`:    `(5[0-3]|[1-4][0-9]|0[1-9])`,
+	`DD`:    `(3[01]|[12][0-9]|[1-9])`,
+	`0D`:    `(3[01]|[12][0-9]|0[1-9])`,
+	`MICRO`: `(` + nr + `)`,
+}
+
+// ParseCalVerFormat parses a format specification such as "YYYY.0M.MICRO" or "YY.MM-MODIFIER"
+func ParseCalVerFormat(spec string) (CalVerFormat, error) {
+	f := &calverFormat{spec: spec}
+	rx := bytes.NewBufferString(`\A`)
+	seen := make(map[string]bool)
+	sep := ``
+	for s := spec; s != ``; {
+		if len(f.tokens) > 0 && sep == `` {
+			m := calverSeparatorPattern.FindString(s)
+			if m == `` {
+				return nil, fmt.Errorf(`'%s' is not a valid calendar version format. Tokens must be separated by '.', '-', or '_'`, spec)
+			}
+			sep = m
+			s = s[len(m):]
+			continue
+		}
+		token := calverTokenPattern.FindString(s)
+		if token == `` {
+			return nil, fmt.Errorf(`'%s' is not a valid calendar version format. Unknown token at '%s'`, spec, s)
+		}
+		kind := calverTokenKind(token)
+		if seen[kind] {
+			return nil, fmt.Errorf(`'%s' is not a valid calendar version format. More than one %s token`, spec, kind)
+		}
+		seen[kind] = true
+		s = s[len(token):]
+		if token == `MODIFIER` {
+			if s != `` || len(f.tokens) == 0 {
+				return nil, fmt.Errorf(`'%s' is not a valid calendar version format. MODIFIER must be the last token`, spec)
+			}
+			rx.WriteString(`(?:` + regexp.QuoteMeta(sep) + `(` + parts + `))?`)
+		} else {
+			rx.WriteString(regexp.QuoteMeta(sep))
+			rx.WriteString(calverTokenPatterns[token])
+			f.tokens = append(f.tokens, token)
+		}
+		f.separators = append(f.separators, sep)
+		sep = ``
+	}
+	if len(f.tokens) == 0 {
+		return nil, fmt.Errorf(`'%s' is not a valid calendar version format`, spec)
+	}
+	if sep != `` {
+		return nil, fmt.Errorf(`'%s' is not a valid calendar version format. It cannot end with a separator`, spec)
+	}
+	if seen[`day`] && !seen[`month`] || seen[`month`] && seen[`week`] || (seen[`month`] || seen[`week`]) && !seen[`year`] {
+		return nil, fmt.Errorf(`'%s' is not a valid calendar version format. It does not describe a valid date`, spec)
+	}
+	rx.WriteString(`\z`)
+	f.pattern = regexp.MustCompile(rx.String())
+	return f, nil
+}
+
+// MustParseCalVerFormat is like ParseCalVerFormat but panics if the specification is invalid
+func MustParseCalVerFormat(spec string) CalVerFormat {
+	f, err := ParseCalVerFormat(spec)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func calverTokenKind(token string) string {
+	switch token {
+	case `YYYY`, `YY`, `0Y`:
+		return `year`
+	case `MM`, `0M`:
+		return `month`
+	case `WW`, `0W`:
+		return `week`
+	case `DD`, `0D`:
+		return `day`
+	case `MICRO`:
+		return `micro`
+	default:
+		return `modifier`
+	}
+}
+
+func (f *calverFormat) Parse(str string) (CalVer, error) {
+	m := f.pattern.FindStringSubmatch(str)
+	if m == nil {
+		return nil, fmt.Errorf(`the string '%s' does not represent a valid calendar version of format %s`, str, f.spec)
+	}
+	segments := make([]int, len(f.tokens))
+	for idx := range f.tokens {
+		n, err := strconv.Atoi(m[idx+1])
+		if err != nil {
+			return nil, fmt.Errorf(`the string '%s' does not represent a valid calendar version of format %s`, str, f.spec)
+		}
+		segments[idx] = n
+	}
+	var modifier []interface{}
+	if len(m) > len(f.tokens)+1 {
+		var err error
+		if modifier, err = splitParts(`modifier`, m[len(f.tokens)+1], true); err != nil {
+			return nil, err
+		}
+	}
+	v := &calver{f, segments, modifier}
+	if !v.isValidDate() {
+		return nil, fmt.Errorf(`the string '%s' does not represent a valid date`, str)
+	}
+	return v, nil
+}
+
+func (f *calverFormat) String() string {
+	return f.spec
+}
+
+func (f *calverFormat) Today(clock func() time.Time) CalVer {
+	return &calver{f, f.periodOf(clock), nil}
+}
+
+// periodOf returns the segments that correspond to the time of the given clock. The MICRO segment is zero.
+// A format with a week token uses the ISO 8601 year that the week belongs to, so December 30, 2024 is in
+// week 1 of 2025.
+func (f *calverFormat) periodOf(clock func() time.Time) []int {
+	if clock == nil {
+		clock = time.Now
+	}
+	t := clock()
+	year := t.Year()
+	isoYear, week := t.ISOWeek()
+	for _, token := range f.tokens {
+		if calverTokenKind(token) == `week` {
+			year = isoYear
+		}
+	}
+	segments := make([]int, len(f.tokens))
+	for idx, token := range f.tokens {
+		switch token {
+		case `YYYY`:
+			segments[idx] = year
+		case `YY`, `0Y`:
+			segments[idx] = year - 2000
+		case `MM`, `0M`:
+			segments[idx] = int(t.Month())
+		case `WW`, `0W`:
+			segments[idx] = week
+		case `DD`, `0D`:
+			segments[idx] = t.Day()
+		}
+	}
+	return segments
+}
+
+func (v *calver) Bump(clock func() time.Time) (CalVer, error) {
+	segments := v.format.periodOf(clock)
+	cmp := 0
+	micro := -1
+	for idx, token := range v.format.tokens {
+		if token == `MICRO` {
+			micro = idx
+			continue
+		}
+		if cmp == 0 {
+			cmp = segments[idx] - v.segments[idx]
+		}
+	}
+	if cmp < 0 {
+		return nil, fmt.Errorf(`unable to bump calendar version %s since the current date precedes it`, v)
+	}
+	if cmp == 0 {
+		if micro < 0 {
+			return nil, fmt.Errorf(`unable to bump calendar version %s since its format %s has no MICRO token`, v, v.format.spec)
+		}
+		segments[micro] = v.segments[micro]
+		if v.modifier == nil {
+			segments[micro]++
+		}
+	}
+	return &calver{v.format, segments, nil}, nil
+}
+
+func (v *calver) CompareTo(other CalVer) int {
+	o := other.(*calver)
+	top := len(v.segments)
+	if len(o.segments) < top {
+		top = len(o.segments)
+	}
+	for idx := 0; idx < top; idx++ {
+		if cmp := v.segments[idx] - o.segments[idx]; cmp != 0 {
+			return cmp
+		}
+	}
+	if cmp := len(v.segments) - len(o.segments); cmp != 0 {
+		return cmp
+	}
+	return comparePreReleases(v.modifier, o.modifier, nil)
+}
+
+func (v *calver) Format() CalVerFormat {
+	return v.format
+}
+
+func (v *calver) Modifier() string {
+	bld := bytes.NewBufferString(``)
+	writeParts(v.modifier, bld)
+	return bld.String()
+}
+
+func (v *calver) Segments() []int {
+	return append(make([]int, 0, len(v.segments)), v.segments...)
+}
+
+func (v *calver) String() string {
+	bld := bytes.NewBufferString(``)
+	for idx, token := range v.format.tokens {
+		bld.WriteString(v.format.separators[idx])
+		switch token {
+		case `0Y`, `0M`, `0W`, `0D`:
+			fmt.Fprintf(bld, `%02d`, v.segments[idx])
+		default:
+			fmt.Fprintf(bld, `%d`, v.segments[idx])
+		}
+	}
+	if v.modifier != nil {
+		if len(v.format.separators) > len(v.format.tokens) {
+			bld.WriteString(v.format.separators[len(v.format.tokens)])
+		} else {
+			bld.WriteString(`-`)
+		}
+		writeParts(v.modifier, bld)
+	}
+	return bld.String()
+}
+
+func (v *calver) ToVersion() (Version, error) {
+	if len(v.segments) > 3 {
+		return nil, fmt.Errorf(`the calendar version %s has more than three numeric segments and cannot be represented as a semantic version`, v)
+	}
+	triplet := make([]int, 3)
+	copy(triplet, v.segments)
+	return NewVersion2(triplet[0], triplet[1], triplet[2], v.Modifier())
+}
+
+// calverString returns the string representation of a version that was converted from a calendar
+// version of the given format
+func calverString(f *calverFormat, v Version) string {
+	segments := make([]int, len(f.tokens))
+	copy(segments, []int{v.Major(), v.Minor(), v.Patch()})
+	return (&calver{f, segments, v.(*version).preRelease}).String()
+}
+
+func (v *calver) isValidDate() bool {
+	year, month, day, week := -1, -1, -1, -1
+	for idx, token := range v.format.tokens {
+		switch calverTokenKind(token) {
+		case `year`:
+			year = v.segments[idx]
+			if token != `YYYY` {
+				year += 2000
+			}
+		case `month`:
+			month = v.segments[idx]
+		case `week`:
+			week = v.segments[idx]
+		case `day`:
+			day = v.segments[idx]
+		}
+	}
+	if day > 0 {
+		t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+		return t.Day() == day
+	}
+	if week > 0 {
+		// December 28 is always in the last ISO week of its year
+		_, last := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+		return week <= last
+	}
+	return true
+}
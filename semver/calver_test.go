@@ -0,0 +1,65 @@
+package semver_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleParseCalVerFormat() {
+	f := semver.MustParseCalVerFormat(`YYYY.0M.MICRO-MODIFIER`)
+	for _, s := range []string{`2026.04.1-hotfix`, `2026.10.16`, `2026.13.1`, `2026.4.1`} {
+		v, err := f.Parse(s)
+		if err == nil {
+			fmt.Printf("%s %v %q\n", v, v.Segments(), v.Modifier())
+		} else {
+			fmt.Println(err)
+		}
+	}
+	// Output:
+	// 2026.04.1-hotfix [2026 4 1] "hotfix"
+	// 2026.10.16 [2026 10 16] ""
+	// the string '2026.13.1' does not represent a valid calendar version of format YYYY.0M.MICRO-MODIFIER
+	// the string '2026.4.1' does not represent a valid calendar version of format YYYY.0M.MICRO-MODIFIER
+}
+
+func ExampleCalVer_Bump() {
+	clock := func() time.Time { return time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC) }
+	f := semver.MustParseCalVerFormat(`YY.0M.0D.MICRO`)
+	v, _ := f.Parse(`26.10.16.0`)
+	v, _ = v.Bump(clock)
+	fmt.Println(v)
+	v, _ = f.Parse(`26.09.30.3`)
+	v, _ = v.Bump(clock)
+	fmt.Println(v)
+	_, err := f.Parse(`26.02.30.0`)
+	fmt.Println(err)
+	// Output:
+	// 26.10.16.1
+	// 26.10.16.0
+	// the string '26.02.30.0' does not represent a valid date
+}
+
+func ExampleCalVerFormat_Today() {
+	clock := func() time.Time { return time.Date(2024, time.December, 30, 12, 0, 0, 0, time.UTC) }
+	fmt.Println(semver.MustParseCalVerFormat(`YYYY.WW.MICRO`).Today(clock))
+	fmt.Println(semver.MustParseCalVerFormat(`YYYY.0M.MICRO`).Today(clock))
+	// Output:
+	// 2025.1.0
+	// 2024.12.0
+}
+
+func ExampleCalVer_ToVersion() {
+	f := semver.MustParseCalVerFormat(`YYYY.MM.MICRO`)
+	rng := semver.MustParseVersionRange(`>=2026.1.0`)
+	for _, s := range []string{`2025.12.4`, `2026.1.0`, `2026.10.2`} {
+		cv, _ := f.Parse(s)
+		v, _ := cv.ToVersion()
+		fmt.Println(v, rng.Includes(v))
+	}
+	// Output:
+	// 2025.12.4 false
+	// 2026.1.0 true
+	// 2026.10.2 true
+}
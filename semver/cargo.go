@@ -0,0 +1,71 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var cargoComparatorPattern = regexp.MustCompile(`\A(=|>=|<=|>|<|~|\^)?\s*(?:` + partial + `)\z`)
+var cargoSplit = regexp.MustCompile(`\s*,\s*`)
+
+// ParseCargoVersionRange parses a version requirement that conforms to the syntax used by Cargo. See
+// https://doc.rust-lang.org/cargo/reference/specifying-dependencies.html for a full description.
+//
+// Comparators are separated by comma and must all match. A comparator without an operator is a caret
+// requirement unless it contains a wildcard, so "1.2" means "^1.2". The caret only allows updates that
+// do not modify the left-most non-zero number, i.e. "^0.0.3" means ">=0.0.3 <0.0.4".
+func ParseCargoVersionRange(str string) (VersionRange, error) {
+	vr := strings.TrimSpace(str)
+	if vr == `` {
+		return nil, fmt.Errorf(`'%s' is not a valid Cargo version requirement`, str)
+	}
+
+	ranges := []abstractRange{lowestLb}
+	for _, comparator := range cargoSplit.Split(vr, -1) {
+		m := cargoComparatorPattern.FindStringSubmatch(comparator)
+		if m == nil {
+			return nil, fmt.Errorf(`'%s' is not a valid Cargo version requirement`, comparator)
+		}
+		var rng abstractRange
+		var err error
+		switch m[1] {
+		case `=`:
+			rng, err = createXRange(m, 2)
+		case `~`:
+			rng, err = createTildeRange(m, 2)
+		case `^`:
+			rng, err = createZeroAwareCaretRange(m, 2)
+		case `>`:
+			rng, err = createGtRange(m, 2)
+		case `>=`:
+			rng, err = createGtEqRange(m, 2)
+		case `<`:
+			rng, err = createLtRange(m, 2)
+		case `<=`:
+			rng, err = createLtEqRange(m, 2)
+		default:
+			if hasWildcard(m, 2) {
+				rng, err = createXRange(m, 2)
+			} else {
+				rng, err = createZeroAwareCaretRange(m, 2)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		ranges = andRanges(ranges, []abstractRange{rng})
+	}
+	return newVersionRange(str, ranges), nil
+}
+
+// hasWildcard returns true if one of the major, minor, or patch groups of a partial match is a wildcard
+func hasWildcard(rxGroup []string, startInMatcher int) bool {
+	for i := 0; i < 3; i++ {
+		switch rxGroup[startInMatcher+i] {
+		case `x`, `X`, `*`:
+			return true
+		}
+	}
+	return false
+}
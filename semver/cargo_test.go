@@ -0,0 +1,26 @@
+package semver_test
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleParseCargoVersionRange() {
+	for _, s := range []string{`1.2`, `^0.0.3`, `~1.2`, `>= 1.2, < 1.5`, `=1.2`, `1.*`, `*`} {
+		rng, err := semver.ParseCargoVersionRange(s)
+		if err == nil {
+			fmt.Println(rng.NormalizedString())
+		} else {
+			fmt.Println(err)
+		}
+	}
+	// Output:
+	// >=1.2.0 <2.0.0
+	// >=0.0.3 <0.0.4
+	// >=1.2.0 <1.3.0
+	// >=1.2.0 <1.5.0
+	// >=1.2.0 <1.3.0
+	// >=1.0.0 <2.0.0
+	// >=0.0.0-
+}
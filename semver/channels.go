@@ -0,0 +1,171 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// Channels maps release channel names, also known as dist-tags, such as "latest", "next", "beta", or
+// "lts" to a version or to a version range. A channel that has been mapped to a version can only move
+// forward, i.e. it can only be changed to a version of higher or equal precedence or to a range that
+// includes such a version. This also holds after the channel has been mapped to a range.
+type Channels interface {
+	// Delete removes a channel. Deleting a channel and setting it again is the only way to move a
+	// channel backwards.
+	Delete(name string)
+
+	// Get returns the range of a channel. A channel that is mapped to a version is returned as a range
+	// that only includes that version. The returned boolean is false when the channel doesn't exist.
+	Get(name string) (VersionRange, bool)
+
+	// Names returns the names of all channels in alphabetical order
+	Names() []string
+
+	// ParseVersionRange returns the range of the channel with the given name, or when no such channel
+	// exists, the result of parsing the string using ParseVersionRange.
+	ParseVersionRange(str string) (VersionRange, error)
+
+	// Promote moves the channel named to so that it points to the version of the channel named from,
+	// e.g. from "next" to "latest". An error is returned if from is not mapped to a version or if to
+	// would move backwards.
+	Promote(from, to string) error
+
+	// Set maps a channel to a version. An error is returned if the name is invalid, if the version is nil,
+	// or if the channel would move backwards.
+	Set(name string, v Version) error
+
+	// SetRange maps a channel to a version range, e.g. "lts" to "^1.0.0". An error is returned if the
+	// name is invalid, if the range is nil, or if the channel would move backwards.
+	SetRange(name string, r VersionRange) error
+
+	// Version returns the version of a channel and true, or nil and false if the channel doesn't exist
+	// or is mapped to a range.
+	Version(name string) (Version, bool)
+}
+
+// channel is mapped to a range when rng is set. The version is then the version that the channel was last
+// mapped to, if any, which is retained so that the channel cannot move backwards.
+type channel struct {
+	version Version
+	rng     VersionRange
+}
+
+type channels map[string]*channel
+
+var channelNamePattern = regexp.MustCompile(`\A[A-Za-z][0-9A-Za-z._-]*\z`)
+
+// NewChannels creates channels from the given dist-tags, which may be nil
+func NewChannels(tags map[string]Version) (Channels, error) {
+	cs := make(channels, len(tags))
+	for name, v := range tags {
+		if err := cs.Set(name, v); err != nil {
+			return nil, err
+		}
+	}
+	return cs, nil
+}
+
+func (cs channels) Delete(name string) {
+	delete(cs, name)
+}
+
+func (cs channels) Get(name string) (VersionRange, bool) {
+	c, ok := cs[name]
+	if !ok {
+		return nil, false
+	}
+	if c.rng != nil {
+		return c.rng, true
+	}
+	return ExactVersionRange(c.version), true
+}
+
+func (cs channels) Names() []string {
+	names := make([]string, 0, len(cs))
+	for name := range cs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (cs channels) ParseVersionRange(str string) (VersionRange, error) {
+	if r, ok := cs.Get(str); ok {
+		return r, nil
+	}
+	return ParseVersionRange(str)
+}
+
+func (cs channels) Promote(from, to string) error {
+	v, ok := cs.Version(from)
+	if !ok {
+		return fmt.Errorf(`the channel '%s' is not mapped to a version and cannot be promoted`, from)
+	}
+	return cs.Set(to, v)
+}
+
+func (cs channels) Set(name string, v Version) error {
+	if err := checkChannelName(name); err != nil {
+		return err
+	}
+	if v == nil {
+		return fmt.Errorf(`the channel '%s' cannot be mapped to a nil version`, name)
+	}
+	if err := cs.checkForward(name, ExactVersionRange(v)); err != nil {
+		return err
+	}
+	cs[name] = &channel{version: v}
+	return nil
+}
+
+func (cs channels) SetRange(name string, r VersionRange) error {
+	if err := checkChannelName(name); err != nil {
+		return err
+	}
+	if r == nil {
+		return fmt.Errorf(`the channel '%s' cannot be mapped to a nil version range`, name)
+	}
+	if err := cs.checkForward(name, r); err != nil {
+		return err
+	}
+	c := &channel{rng: r}
+	if old, ok := cs[name]; ok {
+		c.version = old.version
+	}
+	cs[name] = c
+	return nil
+}
+
+func (cs channels) Version(name string) (Version, bool) {
+	if c, ok := cs[name]; ok && c.rng == nil {
+		return c.version, true
+	}
+	return nil, false
+}
+
+// checkForward returns an error if the channel with the given name has been mapped to a version and the
+// given range includes no version of higher or equal precedence
+func (cs channels) checkForward(name string, r VersionRange) error {
+	c, ok := cs[name]
+	if !ok || c.version == nil {
+		return nil
+	}
+	atLeast := newVersionRange(`>=`+c.version.String(), []abstractRange{&gtEqRange{simpleRange{c.version}}})
+	if r.Intersection(atLeast) == nil {
+		return fmt.Errorf(`the channel '%s' cannot move backwards from %s to %s`, name, c.version, r)
+	}
+	return nil
+}
+
+// checkChannelName returns an error unless the name is a valid channel name. A name that is also a valid
+// version range, such as "x", would be ambiguous and is therefore not valid.
+func checkChannelName(name string) error {
+	if !channelNamePattern.MatchString(name) {
+		return fmt.Errorf(`'%s' is not a valid channel name`, name)
+	}
+	if _, err := ParseVersionRange(name); err == nil {
+		return fmt.Errorf(`'%s' is not a valid channel name since it is a version range`, name)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package semver_test
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleChannels() {
+	cs, err := semver.NewChannels(map[string]semver.Version{
+		`latest`: semver.MustParseVersion(`1.4.2`),
+		`next`:   semver.MustParseVersion(`2.0.0-rc.1`),
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(cs.SetRange(`lts`, semver.MustParseVersionRange(`^1.0.0`)))
+	for _, s := range []string{`latest`, `lts`, `>=1.2.0`, `beta`} {
+		r, err := cs.ParseVersionRange(s)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Println(s, r.NormalizedString())
+	}
+	fmt.Println(cs.Names())
+	// Output:
+	// <nil>
+	// latest 1.4.2
+	// lts >=1.0.0 <2.0.0
+	// >=1.2.0 >=1.2.0
+	// 'beta' is not a valid version range
+	// [latest lts next]
+}
+
+func ExampleChannels_Promote() {
+	cs, _ := semver.NewChannels(map[string]semver.Version{
+		`latest`: semver.MustParseVersion(`1.4.2`),
+		`next`:   semver.MustParseVersion(`2.0.0`),
+	})
+	fmt.Println(cs.Promote(`next`, `latest`))
+	fmt.Println(cs.Version(`latest`))
+	fmt.Println(cs.Set(`latest`, semver.MustParseVersion(`1.5.0`)))
+	fmt.Println(cs.SetRange(`lts`, semver.MustParseVersionRange(`^1.0.0`)))
+	fmt.Println(cs.Promote(`lts`, `latest`))
+	fmt.Println(cs.Set(`x`, semver.MustParseVersion(`1.0.0`)))
+	fmt.Println(cs.Set(`beta`, nil))
+	fmt.Println(cs.SetRange(`beta`, nil))
+	_, ok := cs.Get(`beta`)
+	fmt.Println(ok)
+	fmt.Println(cs.SetRange(`latest`, semver.MustParseVersionRange(`^1.0.0`)))
+	fmt.Println(cs.SetRange(`latest`, semver.MustParseVersionRange(`>=1.5.0`)))
+	fmt.Println(cs.Set(`latest`, semver.MustParseVersion(`1.9.0`)))
+	fmt.Println(cs.Set(`latest`, semver.MustParseVersion(`2.1.0`)))
+	// Output:
+	// <nil>
+	// 2.0.0 true
+	// the channel 'latest' cannot move backwards from 2.0.0 to 1.5.0
+	// <nil>
+	// the channel 'lts' is not mapped to a version and cannot be promoted
+	// 'x' is not a valid channel name since it is a version range
+	// the channel 'beta' cannot be mapped to a nil version
+	// the channel 'beta' cannot be mapped to a nil version range
+	// false
+	// the channel 'latest' cannot move backwards from 2.0.0 to ^1.0.0
+	// <nil>
+	// the channel 'latest' cannot move backwards from 2.0.0 to 1.9.0
+	// <nil>
+}
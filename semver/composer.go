@@ -0,0 +1,157 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var composerPartial = `v?(?:` + partial + `)`
+var composerStability = `(?:@(?i:(stable|rc|beta|alpha|dev)))?`
+var composerConstraintPattern = regexp.MustCompile(`\A(<>|!=|==|=|>=|<=|>|<|~|\^)?` + composerPartial + composerStability + `\z`)
+var composerHyphenPattern = regexp.MustCompile(`\A` + composerPartial + `\s+-\s+` + composerPartial + `\z`)
+var composerOrSplit = regexp.MustCompile(`\s*\|\|?\s*`)
+var composerAndSplit = regexp.MustCompile(`\s*,\s*|\s+`)
+var composerOpWsPattern = regexp.MustCompile(`(<>|!=|==|[<>=~^])\s+`)
+
+// ParseComposerVersionRange parses a version constraint that conforms to the syntax used by Composer. See
+// https://getcomposer.org/doc/articles/versions.md for a full description.
+//
+// Constraints separated by whitespace or comma must all match and alternatives are separated by "||". In
+// contrast to npm, a version without an operator is an exact match and missing numbers in partial versions
+// are zero, so both "1.0" and ">1.0" compare against 1.0.0. The tilde allows the last given number to
+// change, i.e. "~1.2" means ">=1.2.0 <2.0.0". The hyphen range "1.0 - 2.0" means ">=1.0.0 <2.1.0".
+//
+// A stability flag such as "@beta" or "@dev" makes the constraint accept pre-releases of its bounds. The
+// flag "@stable" has no effect.
+func ParseComposerVersionRange(str string) (VersionRange, error) {
+	vr := strings.TrimSpace(str)
+	if vr == `` {
+		return nil, fmt.Errorf(`'%s' is not a valid Composer version constraint`, str)
+	}
+
+	vr = composerOpWsPattern.ReplaceAllString(vr, `$1`)
+	ranges := make([]abstractRange, 0)
+	for _, rangeStr := range composerOrSplit.Split(vr, -1) {
+		if m := composerHyphenPattern.FindStringSubmatch(rangeStr); m != nil {
+			start, ok, err := zeroFilledVersion(m, 1)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				start = nil
+			}
+			end, err := createLtEqRange(m, 6)
+			if err != nil {
+				return nil, err
+			}
+			if start == nil {
+				ranges = append(ranges, end)
+			} else if is := intersection(&gtEqRange{simpleRange{start}}, end); is != nil {
+				ranges = append(ranges, is)
+			}
+			continue
+		}
+
+		and := []abstractRange{lowestLb}
+		for _, constraint := range composerAndSplit.Split(rangeStr, -1) {
+			rngs, err := createComposerRanges(constraint)
+			if err != nil {
+				return nil, err
+			}
+			and = andRanges(and, rngs)
+		}
+		ranges = append(ranges, and...)
+	}
+	return newVersionRange(str, ranges), nil
+}
+
+func createComposerRanges(constraint string) ([]abstractRange, error) {
+	m := composerConstraintPattern.FindStringSubmatch(constraint)
+	if m == nil {
+		return nil, fmt.Errorf(`'%s' is not a valid Composer version constraint`, constraint)
+	}
+
+	op := m[1]
+	if op == `` && hasWildcard(m, 2) {
+		op = `*`
+	}
+
+	var rng abstractRange
+	var err error
+	switch op {
+	case `~`:
+		rng, err = createComposerTildeRange(m, 2)
+	case `^`:
+		rng, err = createZeroAwareCaretRange(m, 2)
+	case `*`:
+		rng, err = createXRange(m, 2)
+	default:
+		v, ok, err := zeroFilledVersion(m, 2)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			if op == `<` || op == `<>` || op == `!=` {
+				return []abstractRange{lowestUb}, nil
+			}
+			return []abstractRange{lowestLb}, nil
+		}
+		switch op {
+		case `>`:
+			rng = &gtRange{simpleRange{withComposerStability(v, m[7])}}
+		case `>=`:
+			rng = &gtEqRange{simpleRange{withComposerStability(v, m[7])}}
+		case `<`:
+			rng = &ltRange{simpleRange{v}}
+		case `<=`:
+			rng = &ltEqRange{simpleRange{v}}
+		case `<>`, `!=`:
+			return notEqualRanges(v), nil
+		default:
+			rng = &eqRange{simpleRange{v}}
+		}
+		return []abstractRange{rng}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if ser, ok := rng.(*startEndRange); ok {
+		if lb, ok := ser.startCompare.(*gtEqRange); ok {
+			rng = &startEndRange{&gtEqRange{simpleRange{withComposerStability(lb.Version, m[7])}}, ser.endCompare}
+		}
+	}
+	return []abstractRange{rng}, nil
+}
+
+// createComposerTildeRange creates a range that allows the last given number to change, i.e. "~1.2"
+// becomes ">=1.2.0 <2.0.0" and "~1.2.3" becomes ">=1.2.3 <1.3.0".
+func createComposerTildeRange(rxGroup []string, startInMatcher int) (abstractRange, error) {
+	major, ok, err := xDigit(rxGroup[startInMatcher])
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return lowestLb, nil
+	}
+	if _, ok, _ := xDigit(rxGroup[startInMatcher+2]); ok {
+		return createTildeRange(rxGroup, startInMatcher)
+	}
+	start, _, err := zeroFilledVersion(rxGroup, startInMatcher)
+	if err != nil {
+		return nil, err
+	}
+	return &startEndRange{
+		&gtEqRange{simpleRange{start}},
+		&ltRange{simpleRange{&version{0, major + 1, 0, 0, nil, nil}}}}, nil
+}
+
+// withComposerStability returns a version that will accept all pre-releases of the given stable version
+// unless the stability flag is empty or "stable".
+func withComposerStability(v Version, stability string) Version {
+	if stability == `` || strings.EqualFold(stability, `stable`) || !v.IsStable() {
+		return v
+	}
+	vi := v.(*version)
+	return &version{vi.epoch, vi.major, vi.minor, vi.patch, minPrereleases, vi.build}
+}
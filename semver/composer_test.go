@@ -0,0 +1,40 @@
+package semver_test
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleParseComposerVersionRange() {
+	for _, s := range []string{`^1.2 || ~2.0`, `1.0 - 2.0`, `>=1.0 <1.1 || >=1.2`, `~1.2.3`, `1.0`, `>=1.0,!=1.0.5,<1.1`} {
+		rng, err := semver.ParseComposerVersionRange(s)
+		if err == nil {
+			fmt.Println(rng.NormalizedString())
+		} else {
+			fmt.Println(err)
+		}
+	}
+	// Output:
+	// >=1.2.0 <3.0.0
+	// >=1.0.0 <2.1.0
+	// >=1.0.0 <1.1.0 || >=1.2.0
+	// >=1.2.3 <1.3.0
+	// 1.0.0
+	// >=1.0.0 <1.0.5 || >1.0.5 <1.1.0
+}
+
+func ExampleParseComposerVersionRange_stability() {
+	v := semver.MustParseVersion(`1.0.0-beta2`)
+	for _, s := range []string{`>=1.0@beta`, `>=1.0`} {
+		rng, err := semver.ParseComposerVersionRange(s)
+		if err == nil {
+			fmt.Println(rng.Includes(v))
+		} else {
+			fmt.Println(err)
+		}
+	}
+	// Output:
+	// true
+	// false
+}
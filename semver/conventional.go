@@ -0,0 +1,146 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// A ConventionalCommit is a commit message that follows the Conventional Commits specification found
+// at https://www.conventionalcommits.org, e.g. "feat(parser)!: drop support for legacy syntax"
+type ConventionalCommit struct {
+	// Type is the type of the commit, e.g. "feat" or "fix"
+	Type string
+
+	// Scope is the optional scope, e.g. "parser"
+	Scope string
+
+	// Breaking is true when the header has a "!" before the colon or a footer is a breaking change
+	Breaking bool
+
+	// Description is the text that follows the colon in the header
+	Description string
+
+	// Body is the text between the header and the footers
+	Body string
+
+	// Footers are the footers in the order they appear
+	Footers []Footer
+}
+
+// A Footer is a trailer of a ConventionalCommit, e.g. "Refs: #123" or "BREAKING CHANGE: ..."
+type Footer struct {
+	Token string
+	Value string
+}
+
+// A BumpRecommendation is the result of RecommendBump
+type BumpRecommendation struct {
+	// Type is the release type used to increment the current version. Only meaningful when Commits
+	// is not empty
+	Type ReleaseType
+
+	// Next is the recommended next version. It is equal to the current version when Commits is empty
+	Next Version
+
+	// Commits are the commits that drove the decision, i.e. the commits that require a release of
+	// the recommended Type
+	Commits []*ConventionalCommit
+}
+
+var conventionalHeaderPattern = regexp.MustCompile(`\A([A-Za-z][0-9A-Za-z-]*)(?:\(([^()\r\n]*)\))?(!)?: ([^\r\n]+)\z`)
+var footerPattern = regexp.MustCompile(`\A(BREAKING CHANGE|[A-Za-z][0-9A-Za-z-]*)(?:: | #)(.*)\z`)
+
+// ParseConventionalCommit parses a commit message that follows the Conventional Commits specification.
+// The types are matched without regard to case.
+func ParseConventionalCommit(message string) (*ConventionalCommit, error) {
+	lines := strings.Split(strings.Replace(strings.TrimSpace(message), "\r\n", "\n", -1), "\n")
+	m := conventionalHeaderPattern.FindStringSubmatch(lines[0])
+	if m == nil {
+		return nil, fmt.Errorf(`the string '%s' is not a valid conventional commit header`, lines[0])
+	}
+	c := &ConventionalCommit{
+		Type:        strings.ToLower(m[1]),
+		Scope:       m[2],
+		Breaking:    m[3] != ``,
+		Description: strings.TrimSpace(m[4])}
+
+	// Footers start at the first line of the last paragraph when that line is a footer
+	rest := lines[1:]
+	bodyEnd := len(rest)
+	for idx := len(rest) - 1; idx >= 0; idx-- {
+		if strings.TrimSpace(rest[idx]) == `` {
+			if idx+1 < len(rest) && footerPattern.MatchString(rest[idx+1]) {
+				bodyEnd = idx
+			}
+			break
+		}
+	}
+	for _, line := range rest[bodyEnd:] {
+		if fm := footerPattern.FindStringSubmatch(line); fm != nil {
+			c.Footers = append(c.Footers, Footer{fm[1], fm[2]})
+		} else if len(c.Footers) > 0 {
+			f := &c.Footers[len(c.Footers)-1]
+			f.Value += "\n" + line
+		}
+	}
+	for idx := range c.Footers {
+		f := &c.Footers[idx]
+		f.Value = strings.TrimSpace(f.Value)
+		if f.Token == `BREAKING CHANGE` || f.Token == `BREAKING-CHANGE` {
+			c.Breaking = true
+		}
+	}
+	c.Body = strings.TrimSpace(strings.Join(rest[:bodyEnd], "\n"))
+	return c, nil
+}
+
+// ReleaseType returns the release type that the commit requires and true, or false when the commit
+// doesn't require a release. A breaking change requires a Major release unless the given version is
+// below 1.0.0, in which case it requires a Minor release. A "feat" requires a Minor release and a
+// "fix" requires a Patch release.
+func (c *ConventionalCommit) ReleaseType(current Version) (ReleaseType, bool) {
+	switch {
+	case c.Breaking:
+		if current.Major() == 0 {
+			return Minor, true
+		}
+		return Major, true
+	case c.Type == `feat`:
+		return Minor, true
+	case c.Type == `fix`:
+		return Patch, true
+	}
+	return Patch, false
+}
+
+// RecommendBump returns the version that should follow the given version based on the given commit
+// messages. Messages that are not conventional commits are ignored.
+func RecommendBump(current Version, messages []string) (*BumpRecommendation, error) {
+	rec := &BumpRecommendation{Next: current, Commits: []*ConventionalCommit{}}
+	for _, msg := range messages {
+		c, err := ParseConventionalCommit(msg)
+		if err != nil {
+			continue
+		}
+		t, ok := c.ReleaseType(current)
+		if !ok {
+			continue
+		}
+		switch {
+		case len(rec.Commits) == 0 || t > rec.Type:
+			rec.Type = t
+			rec.Commits = []*ConventionalCommit{c}
+		case t == rec.Type:
+			rec.Commits = append(rec.Commits, c)
+		}
+	}
+	if len(rec.Commits) > 0 {
+		next, err := Increment(current, rec.Type, ``)
+		if err != nil {
+			return nil, err
+		}
+		rec.Next = next
+	}
+	return rec, nil
+}
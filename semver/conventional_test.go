@@ -0,0 +1,62 @@
+package semver_test
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleParseConventionalCommit() {
+	c, err := semver.ParseConventionalCommit(`feat(parser): add support for epochs
+
+Versions may now be prefixed with an epoch.
+
+BREAKING CHANGE: the Version interface has a new
+Epoch method
+Refs: #123`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("%s %q %t %q\n", c.Type, c.Scope, c.Breaking, c.Description)
+	fmt.Printf("%q\n", c.Body)
+	for _, f := range c.Footers {
+		fmt.Printf("%s: %q\n", f.Token, f.Value)
+	}
+	// Output:
+	// feat "parser" true "add support for epochs"
+	// "Versions may now be prefixed with an epoch."
+	// BREAKING CHANGE: "the Version interface has a new\nEpoch method"
+	// Refs: "#123"
+}
+
+func ExampleRecommendBump() {
+	messages := []string{
+		`fix: handle empty input`,
+		`docs: update README`,
+		`feat(cli): add sort command`,
+		`Merge branch 'main'`,
+		`feat: add diff command`,
+	}
+	for _, s := range []string{`1.4.2`, `2.0.0-rc.1`} {
+		rec, _ := semver.RecommendBump(semver.MustParseVersion(s), messages)
+		fmt.Println(s, rec.Type, rec.Next, len(rec.Commits), rec.Commits[0].Description)
+	}
+	// Output:
+	// 1.4.2 minor 1.5.0 2 add sort command
+	// 2.0.0-rc.1 minor 2.0.0 2 add sort command
+}
+
+func ExampleRecommendBump_breaking() {
+	messages := []string{`refactor!: rename Parse to ParseVersion`, `chore: bump dependencies`}
+	for _, s := range []string{`0.3.1`, `1.4.2`} {
+		rec, _ := semver.RecommendBump(semver.MustParseVersion(s), messages)
+		fmt.Println(s, rec.Type, rec.Next)
+	}
+	rec, _ := semver.RecommendBump(semver.MustParseVersion(`1.4.2`), []string{`chore: tidy`})
+	fmt.Println(rec.Next, len(rec.Commits))
+	// Output:
+	// 0.3.1 minor 0.4.0
+	// 1.4.2 major 2.0.0
+	// 1.4.2 0
+}
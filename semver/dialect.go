@@ -0,0 +1,196 @@
+package semver
+
+import "fmt"
+
+// An interval is a flattened view of an abstractRange that is convenient when converting
+// ranges to and from other dialects. A nil start or end denotes an unbounded side.
+type interval struct {
+	start        Version
+	excludeStart bool
+	end          Version
+	excludeEnd   bool
+}
+
+// intervalsOf returns the intervals that make up the given range in ascending order
+func intervalsOf(r VersionRange) []interval {
+	vr := r.(*versionRange)
+	result := make([]interval, 0, len(vr.ranges))
+	for _, ar := range vr.ranges {
+		iv := interval{ar.start(), ar.isExcludeStart(), ar.end(), ar.isExcludeEnd()}
+		if iv.start.Equals(Min) {
+			iv.start = nil
+			iv.excludeStart = false
+		}
+		if iv.end.Equals(Max) {
+			iv.end = nil
+			iv.excludeEnd = false
+		}
+		result = append(result, iv)
+	}
+	for i := 1; i < len(result); i++ {
+		for j := i; j > 0 && result[j].isBefore(result[j-1]); j-- {
+			result[j], result[j-1] = result[j-1], result[j]
+		}
+	}
+	return result
+}
+
+// isBefore returns true if the start of the receiver is lower than the start of the given interval
+func (iv interval) isBefore(o interval) bool {
+	if iv.start == nil {
+		return o.start != nil
+	}
+	if o.start == nil {
+		return false
+	}
+	cmp := iv.start.CompareTo(o.start)
+	return cmp < 0 || cmp == 0 && !iv.excludeStart && o.excludeStart
+}
+
+// hasEpoch returns true if the start or end of the interval has a non zero epoch
+func (iv interval) hasEpoch() bool {
+	return iv.start != nil && iv.start.Epoch() != 0 || iv.end != nil && iv.end.Epoch() != 0
+}
+
+// checkNoEpoch returns an error if one of the given intervals has a version with a non zero epoch
+func checkNoEpoch(r VersionRange, ivs []interval, dialect string) error {
+	for _, iv := range ivs {
+		if iv.hasEpoch() {
+			return fmt.Errorf(`the range '%s' has a version with an epoch which cannot be expressed in %s syntax`, r, dialect)
+		}
+	}
+	return nil
+}
+
+// isEmpty returns true if the interval cannot include any version
+func (iv interval) isEmpty() bool {
+	return iv.start == nil && iv.end != nil && iv.excludeEnd && iv.end.Equals(Min)
+}
+
+// isExact returns true if the interval includes exactly one version
+func (iv interval) isExact() bool {
+	return iv.start != nil && iv.end != nil && !(iv.excludeStart || iv.excludeEnd) && iv.start.Equals(iv.end)
+}
+
+// newBoundedRange creates the abstractRange that corresponds to the given bounds. A nil start or end
+// denotes an unbounded side.
+func newBoundedRange(start Version, excludeStart bool, end Version, excludeEnd bool) abstractRange {
+	var startR abstractRange
+	if start != nil {
+		if excludeStart {
+			startR = &gtRange{simpleRange{start}}
+		} else {
+			startR = &gtEqRange{simpleRange{start}}
+		}
+	}
+	var endR abstractRange
+	if end != nil {
+		if excludeEnd {
+			endR = &ltRange{simpleRange{end}}
+		} else {
+			endR = &ltEqRange{simpleRange{end}}
+		}
+	}
+	switch {
+	case startR == nil && endR == nil:
+		return lowestLb
+	case startR == nil:
+		return endR
+	case endR == nil:
+		return startR
+	case !(excludeStart || excludeEnd) && start.Equals(end):
+		return &eqRange{simpleRange{start}}
+	default:
+		return &startEndRange{startR, endR}
+	}
+}
+
+// andRanges returns the intersection of the two unions of ranges a and b
+func andRanges(a, b []abstractRange) []abstractRange {
+	result := make([]abstractRange, 0, len(a))
+	for _, ar := range a {
+		for _, br := range b {
+			if is := intersection(ar, br); is != nil {
+				result = append(result, is)
+			}
+		}
+	}
+	return result
+}
+
+// notEqualRanges returns the union of ranges that includes all versions except the given version
+func notEqualRanges(v Version) []abstractRange {
+	return []abstractRange{&ltRange{simpleRange{v}}, &gtRange{simpleRange{v}}}
+}
+
+// complementOf returns the union of ranges that includes all versions that are not included by the given range
+func complementOf(ar abstractRange) []abstractRange {
+	result := make([]abstractRange, 0, 2)
+	if ar.isLowerBound() {
+		result = append(result, newBoundedRange(nil, false, ar.start(), !ar.isExcludeStart()))
+	}
+	if ar.isUpperBound() {
+		result = append(result, newBoundedRange(ar.end(), !ar.isExcludeEnd(), nil, false))
+	}
+	if len(result) == 0 {
+		result = append(result, lowestUb)
+	}
+	return result
+}
+
+// zeroFilledVersion creates a version from the groups of a partial match where missing or wildcard minor
+// and patch numbers are replaced with zero. The returned boolean is false when the major number is a wildcard.
+func zeroFilledVersion(rxGroup []string, startInMatcher int) (Version, bool, error) {
+	major, ok, err := xDigit(rxGroup[startInMatcher])
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	minor, _, err := xDigit(rxGroup[startInMatcher+1])
+	if err != nil {
+		return nil, false, err
+	}
+	patch, _, err := xDigit(rxGroup[startInMatcher+2])
+	if err != nil {
+		return nil, false, err
+	}
+	v, err := NewVersion3(major, minor, patch, rxGroup[startInMatcher+3], rxGroup[startInMatcher+4])
+	if err != nil {
+		return nil, false, err
+	}
+	return v, true, nil
+}
+
+// createZeroAwareCaretRange creates a caret range that, in contrast to createCaretRange, only allows updates
+// that do not modify the left-most non-zero number, i.e. "^0.0.3" becomes ">=0.0.3 <0.0.4". This is the caret
+// semantics used by Cargo and Composer.
+func createZeroAwareCaretRange(rxGroup []string, startInMatcher int) (abstractRange, error) {
+	major, ok, err := xDigit(rxGroup[startInMatcher])
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return lowestLb, nil
+	}
+	minor, minorOk, err := xDigit(rxGroup[startInMatcher+1])
+	if err != nil {
+		return nil, err
+	}
+	patch, patchOk, err := xDigit(rxGroup[startInMatcher+2])
+	if err != nil {
+		return nil, err
+	}
+	start, err := NewVersion3(major, minor, patch, rxGroup[startInMatcher+3], rxGroup[startInMatcher+4])
+	if err != nil {
+		return nil, err
+	}
+	var end Version
+	switch {
+	case major > 0 || !minorOk:
+		end = &version{0, major + 1, 0, 0, nil, nil}
+	case minor > 0 || !patchOk:
+		end = &version{0, 0, minor + 1, 0, nil, nil}
+	default:
+		end = &version{0, 0, 0, patch + 1, nil, nil}
+	}
+	return &startEndRange{&gtEqRange{simpleRange{start}}, &ltRange{simpleRange{end}}}, nil
+}
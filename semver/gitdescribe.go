@@ -0,0 +1,101 @@
+package semver
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// A GitDescription is the parsed output of "git describe --tags --long --dirty"
+type GitDescription struct {
+	// Tag is the name of the tag, e.g. "v1.4.2"
+	Tag string
+
+	// Version is the version that the tag represents, e.g. 1.4.2
+	Version Version
+
+	// Distance is the number of commits between the tag and the described commit
+	Distance int
+
+	// Commit is the abbreviated commit hash without the "g" prefix, e.g. "abc1234"
+	Commit string
+
+	// Dirty is true when the working tree had local modifications
+	Dirty bool
+}
+
+// DefaultGitDescribeTemplate is the template used by ParseGitDescribe. The version of an exact tag is used
+// as is. Commits after a stable tag become a "dev" pre-release of the next patch, and commits after a
+// pre-release tag extend its pre-release, so that "v1.4.2-7-gabc1234-dirty" becomes
+// "1.4.3-dev.7+gabc1234.dirty" and "v2.0.0-rc.1-3-gabc1234" becomes "2.0.0-rc.1.dev.3+gabc1234". The
+// build metadata only contains "dirty" when there is no commit, so "v1.4.2-dirty" becomes "1.4.2+dirty".
+const DefaultGitDescribeTemplate = `{{if eq .Distance 0}}{{.Version}}` +
+	`{{else if .Version.IsStable}}{{.Version.NextPatch}}-dev.{{.Distance}}` +
+	`{{else}}{{.Version.ToStable}}-{{.Version.PreRelease}}.dev.{{.Distance}}{{end}}` +
+	`{{if or .Distance .Dirty}}{{if .Commit}}+g{{.Commit}}{{if .Dirty}}.dirty{{end}}{{else}}+dirty{{end}}{{end}}`
+
+var gitDescribePattern = regexp.MustCompile(`\A(.+?)(?:-([0-9]+)-g([0-9a-f]+))?(-dirty)?\z`)
+
+// ParseGitDescription parses the output of "git describe --tags --long --dirty", e.g.
+// "v1.4.2-7-gabc1234-dirty". The output of "git describe" without the --long flag is also accepted.
+// The version is taken from the tag after stripping everything up to the last "/" and an optional "v"
+// prefix, so "api/v1.4.2" is also a valid tag.
+func ParseGitDescription(str string) (*GitDescription, error) {
+	m := gitDescribePattern.FindStringSubmatch(strings.TrimSpace(str))
+	if m == nil {
+		return nil, fmt.Errorf(`the string '%s' is not a valid git description`, str)
+	}
+	tag := m[1]
+	v, err := versionFromTag(tag)
+	if err != nil {
+		return nil, fmt.Errorf(`the string '%s' is not a valid git description: %s`, str, err.Error())
+	}
+	d := &GitDescription{Tag: tag, Version: v, Commit: m[3], Dirty: m[4] != ``}
+	if m[2] != `` {
+		if d.Distance, err = strconv.Atoi(m[2]); err != nil {
+			return nil, fmt.Errorf(`the string '%s' is not a valid git description`, str)
+		}
+	}
+	return d, nil
+}
+
+// ParseGitDescribe parses the output of "git describe --tags --long --dirty" and converts it into a
+// version using the DefaultGitDescribeTemplate.
+func ParseGitDescribe(str string) (Version, error) {
+	return ParseGitDescribeTemplate(str, DefaultGitDescribeTemplate)
+}
+
+// ParseGitDescribeTemplate parses the output of "git describe --tags --long --dirty" and converts it
+// into a version using the given text/template. The template is executed with a *GitDescription and
+// its output must be a valid semantic version.
+func ParseGitDescribeTemplate(str, tmpl string) (Version, error) {
+	d, err := ParseGitDescription(str)
+	if err != nil {
+		return nil, err
+	}
+	return d.Render(tmpl)
+}
+
+// Render executes the given text/template with the receiver and parses its output as a version
+func (d *GitDescription) Render(tmpl string) (Version, error) {
+	t, err := template.New(`version`).Parse(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	bld := bytes.NewBufferString(``)
+	if err = t.Execute(bld, d); err != nil {
+		return nil, err
+	}
+	return ParseVersion(bld.String())
+}
+
+// versionFromTag returns the version of a tag such as "v1.4.2" or "api/v1.4.2"
+func versionFromTag(tag string) (Version, error) {
+	if slash := strings.LastIndexByte(tag, '/'); slash >= 0 {
+		tag = tag[slash+1:]
+	}
+	return ParseVersion(strings.TrimPrefix(tag, `v`))
+}
@@ -0,0 +1,44 @@
+package semver_test
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleParseGitDescribe() {
+	for _, s := range []string{
+		`v1.4.2-7-gabc1234-dirty`,
+		`v1.4.2-0-gabc1234`,
+		`v1.4.2-0-gabc1234-dirty`,
+		`api/v2.0.0-rc.1-3-gabc1234`,
+		`v1.4.2`,
+		`v1.4.2-dirty`,
+		`abc1234`,
+	} {
+		v, err := semver.ParseGitDescribe(s)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Println(v)
+	}
+	// Output:
+	// 1.4.3-dev.7+gabc1234.dirty
+	// 1.4.2
+	// 1.4.2+gabc1234.dirty
+	// 2.0.0-rc.1.dev.3+gabc1234
+	// 1.4.2
+	// 1.4.2+dirty
+	// the string 'abc1234' is not a valid git description: the string 'abc1234' does not represent a valid semantic version
+}
+
+func ExampleParseGitDescribeTemplate() {
+	v, err := semver.ParseGitDescribeTemplate(`v1.4.2-7-gabc1234`, `{{.Version.ToStable}}-snapshot.{{.Distance}}+{{.Commit}}`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(v)
+	// Output: 1.4.2-snapshot.7+abc1234
+}
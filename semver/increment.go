@@ -0,0 +1,178 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A ReleaseType tells which part of a version that Increment should increment
+type ReleaseType int
+
+const (
+	// Patch increments the patch number, or releases a patch pre-release, e.g. 1.2.3 becomes 1.2.4 and
+	// 1.2.4-rc.1 becomes 1.2.4
+	Patch ReleaseType = iota
+
+	// Minor increments the minor number, or releases a minor pre-release, e.g. 1.2.3 becomes 1.3.0 and
+	// 1.3.0-rc.1 becomes 1.3.0
+	Minor
+
+	// Major increments the major number, or releases a major pre-release, e.g. 1.2.3 becomes 2.0.0 and
+	// 2.0.0-rc.1 becomes 2.0.0
+	Major
+
+	// PrePatch increments the patch number and starts a pre-release, e.g. 1.2.3 becomes 1.2.4-0
+	PrePatch
+
+	// PreMinor increments the minor number and starts a pre-release, e.g. 1.2.3 becomes 1.3.0-0
+	PreMinor
+
+	// PreMajor increments the major number and starts a pre-release, e.g. 1.2.3 becomes 2.0.0-0
+	PreMajor
+
+	// PreRelease increments the last number of a pre-release, e.g. 1.2.4-rc.1 becomes 1.2.4-rc.2, or
+	// behaves like PrePatch if the version is stable
+	PreRelease
+)
+
+var releaseTypeNames = []string{`patch`, `minor`, `major`, `prepatch`, `preminor`, `premajor`, `prerelease`}
+
+// ParseReleaseType returns the release type with the given name. The name is matched without regard
+// to case.
+func ParseReleaseType(name string) (ReleaseType, error) {
+	for idx, n := range releaseTypeNames {
+		if strings.EqualFold(n, name) {
+			return ReleaseType(idx), nil
+		}
+	}
+	return Patch, fmt.Errorf(`'%s' is not a valid release type`, name)
+}
+
+func (t ReleaseType) String() string {
+	if t >= 0 && int(t) < len(releaseTypeNames) {
+		return releaseTypeNames[t]
+	}
+	return fmt.Sprintf(`ReleaseType(%d)`, int(t))
+}
+
+// Increment returns a new version where the given release type has been incremented. The preid is an
+// optional pre-release identifier, such as "rc" or "beta", that is used when a pre-release is started.
+// The build suffix is always stripped off. The semantics are the same as those of the "inc" function
+// in the npm "semver" package:
+//
+//	Increment(1.2.3, PreMinor, "beta")          => 1.3.0-beta.0
+//	Increment(1.3.0-beta.0, PreRelease, "")     => 1.3.0-beta.1
+//	Increment(1.3.0-beta.1, PreRelease, "rc")   => 1.3.0-rc.0
+//	Increment(1.3.0-rc.0, Minor, "")            => 1.3.0
+func Increment(v Version, t ReleaseType, preid string) (Version, error) {
+	ov := v.(*version)
+	switch t {
+	case Major:
+		if ov.preRelease != nil && ov.minor == 0 && ov.patch == 0 {
+			return ov.withoutQualifier(), nil
+		}
+		return ov.NextMajor(), nil
+	case Minor:
+		if ov.preRelease != nil && ov.patch == 0 {
+			return ov.withoutQualifier(), nil
+		}
+		return ov.NextMinor(), nil
+	case Patch:
+		if ov.preRelease != nil {
+			return ov.withoutQualifier(), nil
+		}
+		return ov.NextPatch(), nil
+	case PreMajor:
+		return ov.NextMajor().(*version).withPreRelease(startPreRelease(preid))
+	case PreMinor:
+		return ov.NextMinor().(*version).withPreRelease(startPreRelease(preid))
+	case PrePatch:
+		return ov.NextPatch().(*version).withPreRelease(startPreRelease(preid))
+	case PreRelease:
+		if ov.preRelease == nil {
+			return ov.NextPatch().(*version).withPreRelease(startPreRelease(preid))
+		}
+		if preid != `` && fmt.Sprint(ov.preRelease[0]) != preid {
+			return ov.withoutQualifier().withPreRelease(startPreRelease(preid))
+		}
+		pr := make([]interface{}, len(ov.preRelease))
+		copy(pr, ov.preRelease)
+		bumped := false
+		for idx := len(pr) - 1; idx >= 0; idx-- {
+			if n, ok := pr[idx].(int); ok {
+				pr[idx] = n + 1
+				bumped = true
+				break
+			}
+		}
+		if !bumped {
+			pr = append(pr, 0)
+		}
+		return &version{ov.epoch, ov.major, ov.minor, ov.patch, pr, nil}, nil
+	}
+	return nil, fmt.Errorf(`unable to increment version using unknown release type %s`, t)
+}
+
+func startPreRelease(preid string) string {
+	if preid == `` {
+		return `0`
+	}
+	return preid + `.0`
+}
+
+func (v *version) withoutQualifier() *version {
+	return &version{v.epoch, v.major, v.minor, v.patch, nil, nil}
+}
+
+func (v *version) withPreRelease(preRelease string) (Version, error) {
+	ps, err := splitParts(`pre-release`, preRelease, true)
+	if err != nil {
+		return nil, err
+	}
+	return &version{v.epoch, v.major, v.minor, v.patch, ps, nil}, nil
+}
+
+// Diff returns the release type that describes the most significant difference between two versions
+// and true, or false when the versions are equal. The build suffixes are not included in the comparison.
+// A difference in epoch is reported as Major. The semantics are the same as those of the "diff" function
+// in the npm "semver" package, e.g. the difference between 1.2.3 and 1.3.0-rc.1 is PreMinor and the
+// difference between 2.0.0-rc.1 and 2.0.0 is Major.
+func Diff(a, b Version) (ReleaseType, bool) {
+	cmp := a.CompareTo(b)
+	if cmp == 0 {
+		return Patch, false
+	}
+	low, high := a.(*version), b.(*version)
+	if cmp > 0 {
+		low, high = high, low
+	}
+	if low.preRelease != nil && high.preRelease == nil {
+		// Going from a pre-release to a release
+		if low.minor == 0 && low.patch == 0 {
+			return Major, true
+		}
+		if low.withoutQualifier().CompareTo(high.withoutQualifier()) == 0 {
+			if low.minor != 0 && low.patch == 0 {
+				return Minor, true
+			}
+			return Patch, true
+		}
+	}
+	pre := high.preRelease != nil
+	switch {
+	case low.epoch != high.epoch || low.major != high.major:
+		return preType(Major, pre), true
+	case low.minor != high.minor:
+		return preType(Minor, pre), true
+	case low.patch != high.patch:
+		return preType(Patch, pre), true
+	}
+	return PreRelease, true
+}
+
+func preType(t ReleaseType, pre bool) ReleaseType {
+	if pre {
+		return t + PrePatch - Patch
+	}
+	return t
+}
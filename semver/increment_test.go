@@ -0,0 +1,67 @@
+package semver_test
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleIncrement() {
+	v := semver.MustParseVersion(`1.2.3`)
+	for _, step := range []struct {
+		t     semver.ReleaseType
+		preid string
+	}{
+		{semver.PreMinor, `beta`},
+		{semver.PreRelease, ``},
+		{semver.PreRelease, `rc`},
+		{semver.Minor, ``},
+		{semver.Patch, ``},
+		{semver.Major, ``},
+	} {
+		var err error
+		if v, err = semver.Increment(v, step.t, step.preid); err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(step.t, v)
+	}
+	// Output:
+	// preminor 1.3.0-beta.0
+	// prerelease 1.3.0-beta.1
+	// prerelease 1.3.0-rc.0
+	// minor 1.3.0
+	// patch 1.3.1
+	// major 2.0.0
+}
+
+func ExampleParseReleaseType() {
+	t, err := semver.ParseReleaseType(`PreMajor`)
+	fmt.Println(t, err)
+	_, err = semver.ParseReleaseType(`huge`)
+	fmt.Println(err)
+	// Output:
+	// premajor <nil>
+	// 'huge' is not a valid release type
+}
+
+func ExampleDiff() {
+	for _, p := range [][2]string{
+		{`1.2.3`, `1.3.0-rc.1`},
+		{`2.0.0-rc.1`, `2.0.0`},
+		{`1.2.3-rc.1`, `1.2.3`},
+		{`1.2.3-alpha`, `1.2.3-beta`},
+		{`1.2.3`, `1.2.4`},
+		{`1.2.3+a`, `1.2.3+b`},
+	} {
+		t, ok := semver.Diff(semver.MustParseVersion(p[0]), semver.MustParseVersion(p[1]))
+		fmt.Println(p[0], p[1], t, ok)
+	}
+	// Output:
+	// 1.2.3 1.3.0-rc.1 preminor true
+	// 2.0.0-rc.1 2.0.0 major true
+	// 1.2.3-rc.1 1.2.3 patch true
+	// 1.2.3-alpha 1.2.3-beta prerelease true
+	// 1.2.3 1.2.4 patch true
+	// 1.2.3+a 1.2.3+b patch false
+}
@@ -0,0 +1,151 @@
+package semver
+
+import (
+	"bytes"
+	"sort"
+)
+
+// Infer returns the ranges that include all of the given versions, e.g. the versions that a test suite
+// passed against, ranked so that the simplest range comes first. Simplicity is the number of comparators
+// in the parsed range and ranges that are equally simple are ordered by the width of their span, from
+// narrow to wide. The candidates are the tilde and caret ranges of the lowest version, the span from the
+// lowest to the highest version, the union of tilde or caret ranges for each minor or major release, and
+// the union of the versions themselves, which always includes all versions and nothing else. Versions
+// that only differ in build metadata are treated as one version without build metadata. Unions are merged
+// where possible. Nil is returned when no versions are given.
+func Infer(vs []Version) []VersionRange {
+	if len(vs) == 0 {
+		return nil
+	}
+	sorted := append([]Version{}, vs...)
+	SortVersions(sorted)
+	sorted = withoutBuildDuplicates(sorted)
+	min, max := sorted[0], sorted[len(sorted)-1]
+
+	exprs := []string{`~` + min.String(), `^` + min.String()}
+	if !min.Equals(max) {
+		exprs = append(exprs, `>=`+min.String()+` <=`+max.String())
+	}
+	exprs = append(exprs,
+		inferUnion(sorted, `~`, func(a, b Version) bool { return sameMajor(a, b) && a.Minor() == b.Minor() }),
+		inferUnion(sorted, `^`, sameMajor),
+		inferUnion(sorted, ``, Version.Equals))
+
+	candidates := make([]VersionRange, 0, len(exprs))
+nextExpr:
+	for _, expr := range exprs {
+		r, err := ParseVersionRange(expr)
+		if err != nil {
+			continue
+		}
+		for _, v := range sorted {
+			if !r.Includes(v) {
+				continue nextExpr
+			}
+		}
+		for _, c := range candidates {
+			if c.Equals(r) {
+				continue nextExpr
+			}
+		}
+		candidates = append(candidates, r)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		ca, cb := comparatorCount(a), comparatorCount(b)
+		if ca != cb {
+			return ca < cb
+		}
+		return compareSpan(a, b) < 0
+	})
+	return candidates
+}
+
+// inferUnion returns an expression that joins one range for each group of versions with the given
+// operator applied to the lowest version of the group. The versions must be sorted and a version
+// belongs to the same group as the previous version when sameGroup returns true for the two.
+func inferUnion(sorted []Version, op string, sameGroup func(a, b Version) bool) string {
+	bld := bytes.NewBufferString(op + sorted[0].String())
+	first := sorted[0]
+	for _, v := range sorted[1:] {
+		if !sameGroup(first, v) {
+			first = v
+			bld.WriteString(` || ` + op + v.String())
+		}
+	}
+	return bld.String()
+}
+
+func sameMajor(a, b Version) bool {
+	return a.Epoch() == b.Epoch() && a.Major() == b.Major()
+}
+
+// withoutBuildDuplicates returns the sorted versions with each run of versions of equal precedence replaced
+// by one version. A run of versions that only differ in build metadata is replaced by a version without
+// build metadata.
+func withoutBuildDuplicates(sorted []Version) []Version {
+	result := make([]Version, 0, len(sorted))
+	for _, v := range sorted {
+		if n := len(result) - 1; n >= 0 && result[n].CompareTo(v) == 0 {
+			if !result[n].Equals(v) {
+				result[n] = &version{v.Epoch(), v.Major(), v.Minor(), v.Patch(), v.(*version).preRelease, nil}
+			}
+			continue
+		}
+		result = append(result, v)
+	}
+	return result
+}
+
+// comparatorCount returns the number of comparators in the parsed range. A bounded range, such as the
+// result of parsing "~1.2.3" or "1.2.3 - 2.0.1", has two comparators.
+func comparatorCount(r VersionRange) int {
+	count := 0
+	for _, ar := range r.(*versionRange).ranges {
+		if _, ok := ar.(*startEndRange); ok {
+			count += 2
+		} else {
+			count++
+		}
+	}
+	return count
+}
+
+// compareSpan compares the spans from the lowest start to the highest end of the given ranges. The span
+// with the smallest difference between its end and its start, compared by epoch, major, minor, and patch,
+// is the narrowest. Spans of equal width are ordered by their end and then by their start, highest first,
+// so that the result is zero only when the spans are equal.
+func compareSpan(a, b VersionRange) int {
+	as, ae := span(a)
+	bs, be := span(b)
+	aw := []int{ae.Epoch() - as.Epoch(), ae.Major() - as.Major(), ae.Minor() - as.Minor(), ae.Patch() - as.Patch()}
+	bw := []int{be.Epoch() - bs.Epoch(), be.Major() - bs.Major(), be.Minor() - bs.Minor(), be.Patch() - bs.Patch()}
+	for i := range aw {
+		if aw[i] != bw[i] {
+			if aw[i] < bw[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	if c := ae.CompareTo(be); c != 0 {
+		return c
+	}
+	return bs.CompareTo(as)
+}
+
+// span returns the lowest start and the highest end of the given range
+func span(r VersionRange) (Version, Version) {
+	ranges := r.(*versionRange).ranges
+	start, end := ranges[0].start(), ranges[0].end()
+	for _, ar := range ranges[1:] {
+		if s := ar.start(); s.CompareTo(start) < 0 {
+			start = s
+		}
+		if e := ar.end(); e.CompareTo(end) > 0 {
+			end = e
+		}
+	}
+	return start, end
+}
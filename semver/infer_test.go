@@ -0,0 +1,42 @@
+package semver_test
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleInfer() {
+	infer := func(strs ...string) {
+		vs := make([]semver.Version, len(strs))
+		for i, s := range strs {
+			vs[i] = semver.MustParseVersion(s)
+		}
+		for _, r := range semver.Infer(vs) {
+			fmt.Printf("%s (%s)\n", r, r.NormalizedString())
+		}
+		fmt.Println()
+	}
+	infer(`1.2.5`, `1.2.0`, `1.2.3`)
+	infer(`1.4.0`, `1.2.3`, `2.0.1`)
+	infer(`0.3.1`)
+	infer(`1.2.0+b2`, `1.2.0+b1`, `1.3.0`)
+	// Output:
+	// >=1.2.0 <=1.2.5 (>=1.2.0 <=1.2.5)
+	// ~1.2.0 (>=1.2.0 <1.3.0)
+	// ^1.2.0 (>=1.2.0 <2.0.0)
+	// 1.2.0 || 1.2.3 || 1.2.5 (1.2.0 || 1.2.3 || 1.2.5)
+	//
+	// >=1.2.3 <=2.0.1 (>=1.2.3 <=2.0.1)
+	// 1.2.3 || 1.4.0 || 2.0.1 (1.2.3 || 1.4.0 || 2.0.1)
+	// ^1.2.3 || ^2.0.1 (>=1.2.3 <2.0.0 || >=2.0.1 <3.0.0)
+	// ~1.2.3 || ~1.4.0 || ~2.0.1 (>=1.2.3 <1.3.0 || >=1.4.0 <1.5.0 || >=2.0.1 <2.1.0)
+	//
+	// 0.3.1 (0.3.1)
+	// ~0.3.1 (>=0.3.1 <0.4.0)
+	//
+	// >=1.2.0 <=1.3.0 (>=1.2.0 <=1.3.0)
+	// 1.2.0 || 1.3.0 (1.2.0 || 1.3.0)
+	// ~1.2.0 || ~1.3.0 (>=1.2.0 <1.4.0)
+	// ^1.2.0 (>=1.2.0 <2.0.0)
+}
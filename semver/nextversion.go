@@ -0,0 +1,146 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// A Commit is an entry in the history that NextVersion examines
+type Commit struct {
+	// Message is the commit message
+	Message string
+
+	// Tags are the names of the tags that point to the commit, e.g. "v1.4.2"
+	Tags []string
+}
+
+// A BranchRule tells NextVersion how to compute the version of a branch
+type BranchRule struct {
+	// Pattern is matched against the branch name. The first rule with a matching pattern is used.
+	Pattern *regexp.Regexp
+
+	// Increment is the release type used to increment the latest tagged version when commits have
+	// been made after it. A "+semver: major", "+semver: minor", or "+semver: patch" in a commit message
+	// raises the increment, as do the aliases "breaking", "feature", and "fix".
+	Increment ReleaseType
+
+	// Label is the pre-release label. The result is a release version when the label is empty and a
+	// pre-release such as "1.3.0-alpha.4" otherwise, where the number is the count of commits after the
+	// latest tag. The text "{BranchName}" is replaced by the part of the branch name that follows the
+	// match of the Pattern.
+	Label string
+
+	// VersionInName means that a version in the part of the branch name that follows the match of the
+	// Pattern, e.g. the "1.5" in "release/1.5", is used when it is higher than the incremented version
+	VersionInName bool
+}
+
+// DefaultBranchRules are the rules used by NextVersion when no rules are given. They recognize the
+// main, master, release/*, hotfix/*, develop, and feature/* branches. Other branches are labeled with
+// their name.
+var DefaultBranchRules = []*BranchRule{
+	{Pattern: regexp.MustCompile(`\A(?:main|master)\z`), Increment: Patch},
+	{Pattern: regexp.MustCompile(`\Areleases?[/-]`), Increment: Minor, Label: `beta`, VersionInName: true},
+	{Pattern: regexp.MustCompile(`\Ahotfix(?:es)?[/-]`), Increment: Patch, Label: `beta`, VersionInName: true},
+	{Pattern: regexp.MustCompile(`\Adev(?:elop)?\z`), Increment: Minor, Label: `alpha`},
+	{Pattern: regexp.MustCompile(`\Afeatures?[/-]`), Increment: Minor, Label: `{BranchName}`},
+	{Pattern: regexp.MustCompile(`\A`), Increment: Patch, Label: `{BranchName}`},
+}
+
+var semverDirectivePattern = regexp.MustCompile(`\+semver:\s*(breaking|major|feature|minor|fix|patch)\b`)
+var labelIllegalChars = regexp.MustCompile(`[^0-9A-Za-z-]+`)
+
+// NextVersion computes the version of the newest commit on the given branch. The commits must be given
+// in order, newest first, and are typically the first-parent history of the branch. The latest tagged
+// version is the highest version among the tags of the newest commit that has a version tag. Tags that
+// do not represent a version are ignored. Zero is used when no commit has a version tag.
+//
+// The latest tagged version is returned as is when it is the newest commit. Otherwise, the version is
+// incremented and labeled according to the first rule that matches the branch. The DefaultBranchRules
+// are used when rules is nil.
+func NextVersion(branch string, commits []Commit, rules []*BranchRule) (Version, error) {
+	if rules == nil {
+		rules = DefaultBranchRules
+	}
+	var rule *BranchRule
+	var rest string
+	for _, r := range rules {
+		if loc := r.Pattern.FindStringIndex(branch); loc != nil {
+			rule = r
+			rest = branch[loc[1]:]
+			break
+		}
+	}
+	if rule == nil {
+		return nil, fmt.Errorf(`no branch rule matches the branch '%s'`, branch)
+	}
+
+	var base Version = Zero
+	count := 0
+	inc := rule.Increment
+	for _, c := range commits {
+		if tv := highestTagVersion(c.Tags); tv != nil {
+			if count == 0 {
+				return tv, nil
+			}
+			base = tv
+			break
+		}
+		count++
+		for _, m := range semverDirectivePattern.FindAllStringSubmatch(strings.ToLower(c.Message), -1) {
+			if t := directiveType(m[1]); t > inc {
+				inc = t
+			}
+		}
+	}
+
+	next, err := Increment(base, inc, ``)
+	if err != nil {
+		return nil, err
+	}
+	if rule.VersionInName {
+		if nv, err := Coerce(rest); err == nil && nv.CompareTo(next) > 0 {
+			next = nv.(*version).withoutQualifier()
+		}
+	}
+	if rule.Label == `` {
+		return next, nil
+	}
+
+	label := labelIllegalChars.ReplaceAllString(strings.Replace(rule.Label, `{BranchName}`, rest, -1), `-`)
+	label = strings.Trim(label, `-`)
+	if label == `` {
+		return nil, fmt.Errorf(`unable to create a pre-release label for the branch '%s'`, branch)
+	}
+	n := count
+	if pr := base.(*version).preRelease; pr != nil && base.ToStable().CompareTo(next) == 0 && pr[0] == label {
+		// Continue the numbering of a tagged pre-release
+		if last, ok := pr[len(pr)-1].(int); ok {
+			n += last
+		}
+	}
+	return next.(*version).withPreRelease(label + `.` + strconv.Itoa(n))
+}
+
+func highestTagVersion(tags []string) Version {
+	var max Version
+	for _, tag := range tags {
+		if v, err := versionFromTag(tag); err == nil && (max == nil || v.CompareTo(max) > 0) {
+			max = v
+		}
+	}
+	return max
+}
+
+func directiveType(directive string) ReleaseType {
+	switch directive {
+	case `breaking`, `major`:
+		return Major
+	case `feature`, `minor`:
+		return Minor
+	default:
+		return Patch
+	}
+}
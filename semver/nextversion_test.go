@@ -0,0 +1,57 @@
+package semver_test
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleNextVersion() {
+	commits := []semver.Commit{
+		{Message: `Add login page`},
+		{Message: `Fix typo`},
+		{Message: `Release`, Tags: []string{`v1.4.2`, `latest`}},
+		{Message: `Initial commit`, Tags: []string{`v1.4.1`}},
+	}
+	for _, branch := range []string{`main`, `develop`, `feature/JIRA-12_login`, `release/1.6`, `hotfix/1.4.3`} {
+		v, err := semver.NextVersion(branch, commits, nil)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Println(branch, v)
+	}
+	// Output:
+	// main 1.4.3
+	// develop 1.5.0-alpha.2
+	// feature/JIRA-12_login 1.5.0-JIRA-12-login.2
+	// release/1.6 1.6.0-beta.2
+	// hotfix/1.4.3 1.4.3-beta.2
+}
+
+func ExampleNextVersion_directives() {
+	commits := []semver.Commit{
+		{Message: "Remove deprecated API\n\n+semver: breaking"},
+		{Message: `Tagged`, Tags: []string{`v1.4.2`}},
+	}
+	v, _ := semver.NextVersion(`main`, commits, nil)
+	fmt.Println(v)
+	// Output: 2.0.0
+}
+
+func ExampleNextVersion_preRelease() {
+	commits := []semver.Commit{
+		{Message: `Fix regression`},
+		{Message: `Tagged`, Tags: []string{`v1.5.0-beta.2`}},
+	}
+	for _, branch := range []string{`release/1.5`, `main`} {
+		v, _ := semver.NextVersion(branch, commits, nil)
+		fmt.Println(branch, v)
+	}
+	v, _ := semver.NextVersion(`main`, commits[1:], nil)
+	fmt.Println(`tagged`, v)
+	// Output:
+	// release/1.5 1.5.0-beta.3
+	// main 1.5.0
+	// tagged 1.5.0-beta.2
+}
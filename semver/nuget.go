@@ -0,0 +1,202 @@
+package semver
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var nugetVersionPattern = regexp.MustCompile(`\A([0-9]+)(?:\.([0-9]+)(?:\.([0-9]+)(?:\.([0-9]+))?)?)?` + vQualifier + `\z`)
+
+// ParseNuGetVersion parses a version that conforms to the NuGet version syntax. NuGet accepts
+// one to four numeric parts where missing parts are zero, so "1.2" becomes 1.2.0. A semantic version
+// has no counterpart to the fourth (revision) part, so a non zero revision is stored as leading
+// "rev.<revision>" build metadata identifiers, e.g. "1.2.3.4-beta+git" becomes 1.2.3-beta+rev.4.git.
+// The mapping is lossy in that CompareTo and VersionRange.Includes ignore build metadata and hence
+// the revision. Use CompareWithBuild and IncludesWithBuild to take the revision into account.
+//
+// NuGet compares pre-release labels without regard to case. In order for the resulting version to
+// compare correctly with other versions, the pre-release is converted to lower case.
+func ParseNuGetVersion(str string) (Version, error) {
+	if group := nugetVersionPattern.FindStringSubmatch(strings.TrimSpace(str)); group != nil {
+		nbrs := make([]int, 4)
+		for i := range nbrs {
+			if group[i+1] == `` {
+				continue
+			}
+			n, err := strconv.Atoi(group[i+1])
+			if err != nil {
+				return nil, fmt.Errorf(`the string '%s' does not represent a valid NuGet version`, str)
+			}
+			nbrs[i] = n
+		}
+		build := group[6]
+		if nbrs[3] != 0 {
+			rev := `rev.` + strconv.Itoa(nbrs[3])
+			if build == `` {
+				build = rev
+			} else {
+				build = rev + `.` + build
+			}
+		}
+		return NewVersion3(nbrs[0], nbrs[1], nbrs[2], strings.ToLower(group[5]), build)
+	}
+	return nil, fmt.Errorf(`the string '%s' does not represent a valid NuGet version`, str)
+}
+
+// ParseNuGetVersionRange parses a NuGet version range. Both the interval notation, e.g.
+// "[1.0, 2.0)", "(,1.0]", or "[1.0]", and the bare version notation where "1.0" means ">=1.0.0"
+// are accepted.
+//
+// The returned range evaluates versions according to the rules of VersionRange, which means that
+// pre-releases are only included when the range itself mentions a pre-release with the same triplet.
+func ParseNuGetVersionRange(str string) (VersionRange, error) {
+	vr := strings.TrimSpace(str)
+	if vr == `` {
+		return nil, fmt.Errorf(`'%s' is not a valid NuGet version range`, str)
+	}
+
+	first := vr[0]
+	if first != '[' && first != '(' {
+		v, err := ParseNuGetVersion(vr)
+		if err != nil {
+			return nil, err
+		}
+		return newVersionRange(str, []abstractRange{&gtEqRange{simpleRange{v}}}), nil
+	}
+
+	last := vr[len(vr)-1]
+	if len(vr) < 3 || last != ']' && last != ')' {
+		return nil, fmt.Errorf(`'%s' is not a valid NuGet version range`, str)
+	}
+	excludeStart := first == '('
+	excludeEnd := last == ')'
+	bounds := strings.Split(vr[1:len(vr)-1], `,`)
+	switch len(bounds) {
+	case 1:
+		if excludeStart || excludeEnd {
+			return nil, fmt.Errorf(`'%s' is not a valid NuGet version range. An exact version must use square brackets`, str)
+		}
+		v, err := ParseNuGetVersion(bounds[0])
+		if err != nil {
+			return nil, err
+		}
+		return newVersionRange(str, []abstractRange{&eqRange{simpleRange{v}}}), nil
+	case 2:
+		start, err := parseNuGetBound(bounds[0])
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseNuGetBound(bounds[1])
+		if err != nil {
+			return nil, err
+		}
+		if start == nil {
+			excludeStart = false
+		}
+		if end == nil {
+			excludeEnd = false
+		}
+		if start != nil && end != nil {
+			cmp := start.CompareTo(end)
+			if cmp > 0 || cmp == 0 && (excludeStart || excludeEnd) {
+				return nil, fmt.Errorf(`'%s' is not a valid NuGet version range. It does not include any versions`, str)
+			}
+		}
+		return newVersionRange(str, []abstractRange{newBoundedRange(start, excludeStart, end, excludeEnd)}), nil
+	default:
+		return nil, fmt.Errorf(`'%s' is not a valid NuGet version range`, str)
+	}
+}
+
+func parseNuGetBound(str string) (Version, error) {
+	str = strings.TrimSpace(str)
+	if str == `` {
+		return nil, nil
+	}
+	return ParseNuGetVersion(str)
+}
+
+// NuGetVersionString returns the normalized NuGet representation of the given version. The
+// normalized form omits the build metadata except for a revision stored by ParseNuGetVersion,
+// which is written as the fourth part.
+func NuGetVersionString(v Version) string {
+	bld := bytes.NewBufferString(``)
+	fmt.Fprintf(bld, `%d.%d.%d`, v.Major(), v.Minor(), v.Patch())
+	if rev, ok := nugetRevision(v); ok {
+		fmt.Fprintf(bld, `.%d`, rev)
+	}
+	if !v.IsStable() {
+		bld.WriteString(`-`)
+		bld.WriteString(v.PreRelease())
+	}
+	return bld.String()
+}
+
+// nugetRevision returns the revision that ParseNuGetVersion stored in the build metadata of the given
+// version and true, or zero and false if the version has no revision
+func nugetRevision(v Version) (int, bool) {
+	build := v.(*version).build
+	if len(build) < 2 || build[0] != `rev` {
+		return 0, false
+	}
+	rev, err := strconv.Atoi(build[1].(string))
+	if err != nil || rev == 0 {
+		return 0, false
+	}
+	return rev, true
+}
+
+// compareNuGetVersions compares two versions using CompareTo and, when they are equal, by the revisions
+// that ParseNuGetVersion stored in their build metadata
+func compareNuGetVersions(a, b Version) int {
+	cmp := a.CompareTo(b)
+	if cmp == 0 {
+		ar, _ := nugetRevision(a)
+		br, _ := nugetRevision(b)
+		cmp = ar - br
+	}
+	return cmp
+}
+
+// NuGetVersionRangeString returns the normalized NuGet interval notation for the given range, e.g.
+// "[1.0.0, 2.0.0)". An error is returned when the range cannot be expressed in NuGet syntax, which
+// is the case when it consists of more than one interval.
+func NuGetVersionRangeString(r VersionRange) (string, error) {
+	ivs := intervalsOf(r)
+	if len(ivs) != 1 {
+		return ``, fmt.Errorf(`the range '%s' is a union of intervals which cannot be expressed as a NuGet version range`, r)
+	}
+	if err := checkNoEpoch(r, ivs, `NuGet`); err != nil {
+		return ``, err
+	}
+	iv := ivs[0]
+	if iv.isEmpty() {
+		return ``, fmt.Errorf(`the range '%s' does not include any versions and cannot be expressed as a NuGet version range`, r)
+	}
+	if iv.isExact() {
+		return `[` + NuGetVersionString(iv.start) + `]`, nil
+	}
+
+	bld := bytes.NewBufferString(``)
+	if iv.excludeStart || iv.start == nil {
+		bld.WriteString(`(`)
+	} else {
+		bld.WriteString(`[`)
+	}
+	if iv.start != nil {
+		bld.WriteString(NuGetVersionString(iv.start))
+	}
+	bld.WriteString(`, `)
+	if iv.end != nil {
+		bld.WriteString(NuGetVersionString(iv.end))
+	}
+	if iv.excludeEnd || iv.end == nil {
+		bld.WriteString(`)`)
+	} else {
+		bld.WriteString(`]`)
+	}
+	return bld.String(), nil
+}
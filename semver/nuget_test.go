@@ -0,0 +1,75 @@
+package semver_test
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleParseNuGetVersion() {
+	v, err := semver.ParseNuGetVersion(`1.2-Beta.1`)
+	if err == nil {
+		fmt.Println(v)
+		fmt.Println(semver.NuGetVersionString(v))
+	} else {
+		fmt.Println(err)
+	}
+	// Output:
+	// 1.2.0-beta.1
+	// 1.2.0-beta.1
+}
+
+func ExampleParseNuGetVersionRange() {
+	for _, s := range []string{`[1.0, 2.0)`, `(,1.5]`, `[1.2.3]`, `1.0`} {
+		rng, err := semver.ParseNuGetVersionRange(s)
+		if err == nil {
+			fmt.Println(rng.NormalizedString())
+		} else {
+			fmt.Println(err)
+		}
+	}
+	// Output:
+	// >=1.0.0 <2.0.0
+	// <=1.5.0
+	// 1.2.3
+	// >=1.0.0
+}
+
+func ExampleNuGetVersionRangeString() {
+	for _, s := range []string{`>=1.0.0 <2.0.0`, `>1.2.0`, `1.2.3`, `1.x || 3.x`} {
+		str, err := semver.NuGetVersionRangeString(semver.MustParseVersionRange(s))
+		if err == nil {
+			fmt.Println(str)
+		} else {
+			fmt.Println(err)
+		}
+	}
+	// Output:
+	// [1.0.0, 2.0.0)
+	// (1.2.0, )
+	// [1.2.3]
+	// the range '1.x || 3.x' is a union of intervals which cannot be expressed as a NuGet version range
+}
+
+func ExampleParseNuGetVersion_revision() {
+	v := semver.MustParseVersion(`1.2.3+rev.12`)
+	for _, s := range []string{`1.2.3.4`, `1.2.3.4-RC.1+git`, `1.2.3.0`} {
+		nv, err := semver.ParseNuGetVersion(s)
+		if err == nil {
+			fmt.Println(nv, semver.NuGetVersionString(nv), semver.CompareWithBuild(nv, v) < 0)
+		} else {
+			fmt.Println(err)
+		}
+	}
+	rng, _ := semver.ParseNuGetVersionRange(`[1.2.3.4, 2.0)`)
+	fmt.Println(semver.NuGetVersionRangeString(rng))
+	fmt.Println(semver.IncludesWithBuild(rng, v))
+	fmt.Println(semver.IncludesWithBuild(rng, semver.MustParseVersion(`1.2.3+rev.2`)))
+	// Output:
+	// 1.2.3+rev.4 1.2.3.4 true
+	// 1.2.3-rc.1+rev.4.git 1.2.3.4-rc.1 true
+	// 1.2.3 1.2.3 true
+	// [1.2.3.4, 2.0.0) <nil>
+	// true
+	// false
+}
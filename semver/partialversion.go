@@ -0,0 +1,231 @@
+package semver
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+)
+
+// A PartialVersion is a version where the minor and patch numbers, or all numbers, may be omitted or
+// given as a wildcard, e.g. "1", "1.2", "1.x", or "*". A partial version where all numbers are present
+// is equivalent to a Version.
+type PartialVersion interface {
+	fmt.Stringer
+
+	// CompareTo compares the receiver to a version. Return zero if the version matches the receiver,
+	// a negative integer if the receiver is less than the given version, and a positive integer if
+	// the receiver is greater than the given version. Only the numbers that are present in the receiver
+	// are compared, so "1.2" is equal to "1.2.5" and less than "1.3.0".
+	CompareTo(v Version) int
+
+	// Epoch returns the epoch
+	Epoch() int
+
+	// Highest returns the highest version that matches the receiver. Omitted numbers are replaced with
+	// math.MaxInt64, so the highest version of "1.2" is "1.2.9223372036854775807".
+	Highest() Version
+
+	// Includes returns true if the given version is included in the range returned by ToVersionRange
+	Includes(v Version) bool
+
+	// IsComplete returns true if the major, minor, and patch numbers are all present
+	IsComplete() bool
+
+	// Lowest returns the lowest version that matches the receiver. Omitted numbers are replaced with zero.
+	Lowest() Version
+
+	// Major returns the major version number and true, or zero and false if it is omitted
+	Major() (int, bool)
+
+	// Minor returns the minor version number and true, or zero and false if it is omitted
+	Minor() (int, bool)
+
+	// Patch returns the patch version number and true, or zero and false if it is omitted
+	Patch() (int, bool)
+
+	// ToString writes the string representation of this version onto the given Writer.
+	ToString(io.Writer)
+
+	// ToVersion returns the version that corresponds to the receiver or an error if the receiver is
+	// not complete
+	ToVersion() (Version, error)
+
+	// ToVersionRange returns the range implied by the receiver, i.e. "1.2" becomes ">=1.2.0 <1.3.0"
+	ToVersionRange() VersionRange
+}
+
+type partialVersion struct {
+	nbrs []int
+	full *version
+	rng  VersionRange
+}
+
+var partialVersionPattern = regexp.MustCompile(`\Av?` + epoch + `(?:` + partial + `)\z`)
+
+// ParsePartialVersion parses a partial version such as "1", "1.2", "v1.x", "1.2.*", or "1.2.3-rc.1". A
+// pre-release or build suffix is only accepted when all numbers are present. Numbers that follow a
+// wildcard must also be wildcards.
+func ParsePartialVersion(str string) (PartialVersion, error) {
+	m := partialVersionPattern.FindStringSubmatch(str)
+	if m == nil {
+		return nil, fmt.Errorf(`the string '%s' does not represent a valid partial version`, str)
+	}
+	ep, _, err := xDigit(m[1])
+	if err != nil {
+		return nil, err
+	}
+	nbrs := []int{ep}
+	for idx := 2; idx <= 4; idx++ {
+		n, ok, err := xDigit(m[idx])
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			for _, x := range m[idx+1 : 5] {
+				if _, ok, _ := xDigit(x); ok {
+					return nil, fmt.Errorf(`the string '%s' does not represent a valid partial version. A number cannot follow a wildcard`, str)
+				}
+			}
+			break
+		}
+		nbrs = append(nbrs, n)
+	}
+	if len(nbrs) < 4 && (m[5] != `` || m[6] != ``) {
+		return nil, fmt.Errorf(`the string '%s' does not represent a valid partial version. A wildcard cannot have a pre-release or build suffix`, str)
+	}
+
+	rng, err := createXRange(m, 2)
+	if err == nil {
+		rng, err = withEpoch(rng, m[1])
+	}
+	if err != nil {
+		return nil, err
+	}
+	pv := &partialVersion{nbrs: nbrs, rng: newVersionRange(str, []abstractRange{rng})}
+	if len(nbrs) == 4 {
+		pv.full = rng.(*eqRange).Version.(*version)
+	}
+	return pv, nil
+}
+
+// MustParsePartialVersion is like ParsePartialVersion but panics if the string is invalid
+func MustParsePartialVersion(str string) PartialVersion {
+	v, err := ParsePartialVersion(str)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (p *partialVersion) CompareTo(v Version) int {
+	if p.full != nil {
+		return p.full.CompareTo(v)
+	}
+	ov := v.(*version)
+	for idx, n := range p.nbrs {
+		var o int
+		switch idx {
+		case 0:
+			o = ov.epoch
+		case 1:
+			o = ov.major
+		default:
+			o = ov.minor
+		}
+		if n != o {
+			if n < o {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func (p *partialVersion) Epoch() int {
+	return p.nbrs[0]
+}
+
+func (p *partialVersion) Highest() Version {
+	if p.full != nil {
+		return p.full
+	}
+	return p.fill(math.MaxInt64)
+}
+
+func (p *partialVersion) Includes(v Version) bool {
+	return p.rng.Includes(v)
+}
+
+func (p *partialVersion) IsComplete() bool {
+	return p.full != nil
+}
+
+func (p *partialVersion) Lowest() Version {
+	if p.full != nil {
+		return p.full
+	}
+	return p.fill(0)
+}
+
+func (p *partialVersion) Major() (int, bool) {
+	return p.nbr(1)
+}
+
+func (p *partialVersion) Minor() (int, bool) {
+	return p.nbr(2)
+}
+
+func (p *partialVersion) Patch() (int, bool) {
+	return p.nbr(3)
+}
+
+func (p *partialVersion) String() string {
+	bld := bytes.NewBufferString(``)
+	p.ToString(bld)
+	return bld.String()
+}
+
+func (p *partialVersion) ToString(bld io.Writer) {
+	if p.full != nil {
+		p.full.ToString(bld)
+		return
+	}
+	if p.nbrs[0] != 0 {
+		fmt.Fprintf(bld, `%d!`, p.nbrs[0])
+	}
+	if len(p.nbrs) == 1 {
+		io.WriteString(bld, `*`)
+		return
+	}
+	for idx := 1; idx < len(p.nbrs); idx++ {
+		fmt.Fprintf(bld, `%d.`, p.nbrs[idx])
+	}
+	io.WriteString(bld, `x`)
+}
+
+func (p *partialVersion) ToVersion() (Version, error) {
+	if p.full == nil {
+		return nil, fmt.Errorf(`the partial version '%s' cannot be converted to a version since it is not complete`, p)
+	}
+	return p.full, nil
+}
+
+func (p *partialVersion) ToVersionRange() VersionRange {
+	return p.rng
+}
+
+func (p *partialVersion) fill(n int) Version {
+	nbrs := []int{p.nbrs[0], n, n, n}
+	copy(nbrs, p.nbrs)
+	return &version{nbrs[0], nbrs[1], nbrs[2], nbrs[3], nil, nil}
+}
+
+func (p *partialVersion) nbr(idx int) (int, bool) {
+	if idx < len(p.nbrs) {
+		return p.nbrs[idx], true
+	}
+	return 0, false
+}
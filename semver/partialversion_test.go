@@ -0,0 +1,38 @@
+package semver_test
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleParsePartialVersion() {
+	for _, s := range []string{`1`, `1.2`, `v1.x`, `1.2.3`, `*`} {
+		p, err := semver.ParsePartialVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		minor, ok := p.Minor()
+		fmt.Println(p, minor, ok, p.Lowest(), p.ToVersionRange().NormalizedString())
+	}
+	// Output:
+	// 1.x 0 false 1.0.0 >=1.0.0 <2.0.0
+	// 1.2.x 2 true 1.2.0 >=1.2.0 <1.3.0
+	// 1.x 0 false 1.0.0 >=1.0.0 <2.0.0
+	// 1.2.3 2 true 1.2.3 1.2.3
+	// * 0 false 0.0.0 >=0.0.0-
+}
+
+func ExamplePartialVersion_CompareTo() {
+	p := semver.MustParsePartialVersion(`1.2`)
+	for _, s := range []string{`1.1.9`, `1.2.5`, `1.3.0`} {
+		fmt.Println(s, p.CompareTo(semver.MustParseVersion(s)))
+	}
+	fmt.Println(p.Highest().Minor(), p.Highest().Patch() > 1000)
+	// Output:
+	// 1.1.9 1
+	// 1.2.5 0
+	// 1.3.0 -1
+	// 2 true
+}
@@ -0,0 +1,88 @@
+package semver
+
+import (
+	"fmt"
+	"time"
+)
+
+// A PolicyRule allows or denies updates of certain release types
+type PolicyRule struct {
+	// Types are the release types, as classified by Diff, that the rule applies to. The rule applies to
+	// all release types when Types is empty.
+	Types []ReleaseType
+
+	// From restricts the rule to updates from versions that are included in this range, e.g. ">=1.0.0".
+	// The rule applies to updates from all versions when From is nil.
+	From VersionRange
+
+	// Allow tells whether the updates that the rule applies to are allowed or denied
+	Allow bool
+}
+
+// A Policy decides whether an update from one version to another may be applied automatically, e.g. by
+// a dependency bot. The following policy allows patch updates, allows minor updates for versions >=1.0.0,
+// denies everything else, and waits three days after each release:
+//
+//	&Policy{
+//	  Rules: []*PolicyRule{
+//	    {Types: []ReleaseType{Patch}, Allow: true},
+//	    {Types: []ReleaseType{Minor}, From: MustParseVersionRange(`>=1.0.0`), Allow: true},
+//	  },
+//	  MinAge: 72 * time.Hour,
+//	}
+type Policy struct {
+	// Rules are evaluated in order and the first rule that applies to an update decides whether it is
+	// allowed. An update that no rule applies to is denied.
+	Rules []*PolicyRule
+
+	// PreReleases must be true for updates to a pre-release to be allowed
+	PreReleases bool
+
+	// MinAge is the time that must pass after a release before an update to it is allowed
+	MinAge time.Duration
+}
+
+// Evaluate returns true and an empty string if the policy allows an update from one version to another
+// that was released at the given time. Otherwise it returns false and the reason for the denial. The
+// current time is passed as now.
+func (p *Policy) Evaluate(from, to Version, released, now time.Time) (bool, string) {
+	t, ok := Diff(from, to)
+	if !ok || from.CompareTo(to) > 0 {
+		return false, fmt.Sprintf(`%s is not higher than %s`, to, from)
+	}
+	if !(p.PreReleases || to.IsStable()) {
+		return false, fmt.Sprintf(`%s is a pre-release`, to)
+	}
+	if p.MinAge > 0 {
+		if released.IsZero() {
+			return false, fmt.Sprintf(`the release time of %s is unknown`, to)
+		}
+		if age := now.Sub(released); age < p.MinAge {
+			return false, fmt.Sprintf(`%s was released %s ago but must be at least %s old`, to, age, p.MinAge)
+		}
+	}
+	for _, r := range p.Rules {
+		if r.appliesTo(from, t) {
+			if r.Allow {
+				return true, ``
+			}
+			return false, fmt.Sprintf(`the policy denies %s updates from %s to %s`, t, from, to)
+		}
+	}
+	return false, fmt.Sprintf(`no rule allows %s updates from %s to %s`, t, from, to)
+}
+
+func (r *PolicyRule) appliesTo(from Version, t ReleaseType) bool {
+	if r.From != nil && !r.From.Includes(from) {
+		return false
+	}
+	if len(r.Types) == 0 {
+		return true
+	}
+	for _, rt := range r.Types {
+		if rt == t {
+			return true
+		}
+	}
+	return false
+}
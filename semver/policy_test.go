@@ -0,0 +1,48 @@
+package semver_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExamplePolicy_Evaluate() {
+	p := &semver.Policy{
+		Rules: []*semver.PolicyRule{
+			{Types: []semver.ReleaseType{semver.Patch}, Allow: true},
+			{Types: []semver.ReleaseType{semver.Minor}, From: semver.MustParseVersionRange(`>=1.0.0`), Allow: true},
+			{Types: []semver.ReleaseType{semver.Major}, Allow: false},
+		},
+		MinAge: 72 * time.Hour,
+	}
+	now := time.Date(2020, 6, 10, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-30 * 24 * time.Hour)
+	for _, u := range []struct {
+		from, to string
+		released time.Time
+	}{
+		{`1.2.3`, `1.2.4`, old},
+		{`1.2.3`, `1.3.0`, old},
+		{`0.3.1`, `0.4.0`, old},
+		{`1.2.3`, `2.0.0`, old},
+		{`1.2.3`, `1.2.5-rc.1`, old},
+		{`1.2.3`, `1.2.5`, now.Add(-24 * time.Hour)},
+		{`1.2.3`, `1.2.2`, old},
+	} {
+		ok, reason := p.Evaluate(semver.MustParseVersion(u.from), semver.MustParseVersion(u.to), u.released, now)
+		if ok {
+			fmt.Println(u.from, u.to, ok)
+		} else {
+			fmt.Println(u.from, u.to, ok, reason)
+		}
+	}
+	// Output:
+	// 1.2.3 1.2.4 true
+	// 1.2.3 1.3.0 true
+	// 0.3.1 0.4.0 false no rule allows minor updates from 0.3.1 to 0.4.0
+	// 1.2.3 2.0.0 false the policy denies major updates from 1.2.3 to 2.0.0
+	// 1.2.3 1.2.5-rc.1 false 1.2.5-rc.1 is a pre-release
+	// 1.2.3 1.2.5 false 1.2.5 was released 24h0m0s ago but must be at least 72h0m0s old
+	// 1.2.3 1.2.2 false 1.2.2 is not higher than 1.2.3
+}
@@ -0,0 +1,137 @@
+package semver
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A PreReleaseOrder is an alternative to the lexical ordering that Semantic Versioning prescribes for
+// alphanumeric pre-release identifiers. It is opt-in and only affects comparisons made through its
+// methods.
+type PreReleaseOrder struct {
+	// Channels lists the pre-release channels in ascending order, e.g. "dev", "alpha", "beta",
+	// "preview", "rc". An identifier belongs to a channel when it starts with the channel name,
+	// ignoring case, and the remainder is empty or starts with a digit, so "rc10" belongs to "rc".
+	// Identifiers that belong to no channel are lower than those that do.
+	Channels []string
+
+	// Natural enables comparison of digit sequences within alphanumeric identifiers by numeric value,
+	// so that "rc9" is lower than "rc10".
+	Natural bool
+}
+
+// Compare compares two versions using the receiver for the pre-release identifiers. Return zero if
+// the versions are equal, a negative integer if a is less than b, and a positive integer if a is
+// greater than b.
+func (o *PreReleaseOrder) Compare(a, b Version) int {
+	return a.(*version).compareTo(b.(*version), o)
+}
+
+// Includes returns true if the given version is included in the given range when compared using the
+// receiver.
+func (o *PreReleaseOrder) Includes(r VersionRange, v Version) bool {
+	return r.(*versionRange).includesUsing(v, o.Compare)
+}
+
+// MaxSatisfying returns the highest version in the given slice that is included in the given range
+// when compared using the receiver, or nil if no such version exists.
+func (o *PreReleaseOrder) MaxSatisfying(vs []Version, r VersionRange) Version {
+	var max Version
+	for _, v := range vs {
+		if o.Includes(r, v) && (max == nil || o.Compare(v, max) > 0) {
+			max = v
+		}
+	}
+	return max
+}
+
+// SortVersions sorts the given versions in ascending order when compared using the receiver
+func (o *PreReleaseOrder) SortVersions(vs []Version) {
+	sort.SliceStable(vs, func(i, j int) bool { return o.Compare(vs[i], vs[j]) < 0 })
+}
+
+func (o *PreReleaseOrder) compareIdentifiers(a, b string) int {
+	ac, arest := o.channelOf(a)
+	bc, brest := o.channelOf(b)
+	if cmp := ac - bc; cmp != 0 {
+		return cmp
+	}
+	if ac < 0 {
+		arest, brest = a, b
+	}
+	if o.Natural {
+		return compareNatural(arest, brest)
+	}
+	return strings.Compare(arest, brest)
+}
+
+// channelOf returns the index of the channel that the given identifier belongs to along with the
+// remainder of the identifier, or -1 when the identifier belongs to no channel
+func (o *PreReleaseOrder) channelOf(id string) (int, string) {
+	found := -1
+	rest := id
+	for idx, ch := range o.Channels {
+		if len(ch) > len(id) || !strings.EqualFold(ch, id[:len(ch)]) {
+			continue
+		}
+		r := id[len(ch):]
+		if r != `` && (r[0] < '0' || r[0] > '9') {
+			continue
+		}
+		if found < 0 || len(ch) > len(o.Channels[found]) {
+			found = idx
+			rest = r
+		}
+	}
+	return found, rest
+}
+
+// compareNatural compares two strings where sequences of digits are compared by numeric value. Strings
+// that only differ in leading zeros, such as "rc01" and "rc1", are ordered by their raw value so that the
+// order is total.
+func compareNatural(a, b string) int {
+	if cmp := compareNaturalChunks(a, b); cmp != 0 {
+		return cmp
+	}
+	return strings.Compare(a, b)
+}
+
+func compareNaturalChunks(a, b string) int {
+	for a != `` && b != `` {
+		an, arest := leadingChunk(a)
+		bn, brest := leadingChunk(b)
+		ai, aerr := strconv.ParseUint(an, 10, 64)
+		bi, berr := strconv.ParseUint(bn, 10, 64)
+		var cmp int
+		switch {
+		case aerr == nil && berr == nil:
+			if ai < bi {
+				cmp = -1
+			} else if ai > bi {
+				cmp = 1
+			}
+		case aerr == nil:
+			cmp = -1
+		case berr == nil:
+			cmp = 1
+		default:
+			cmp = strings.Compare(an, bn)
+		}
+		if cmp != 0 {
+			return cmp
+		}
+		a, b = arest, brest
+	}
+	return len(a) - len(b)
+}
+
+// leadingChunk splits the given string after its leading sequence of digits or non-digits
+func leadingChunk(s string) (string, string) {
+	digit := s[0] >= '0' && s[0] <= '9'
+	idx := 1
+	for idx < len(s) && (s[idx] >= '0' && s[idx] <= '9') == digit {
+		idx++
+	}
+	return s[:idx], s[idx:]
+}
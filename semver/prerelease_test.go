@@ -0,0 +1,29 @@
+package semver_test
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExamplePreReleaseOrder() {
+	order := &semver.PreReleaseOrder{Channels: []string{`dev`, `alpha`, `beta`, `preview`, `rc`}, Natural: true}
+	vs := []semver.Version{
+		semver.MustParseVersion(`1.0.0-rc10`),
+		semver.MustParseVersion(`1.0.0-preview`),
+		semver.MustParseVersion(`1.0.0`),
+		semver.MustParseVersion(`1.0.0-rc9`),
+		semver.MustParseVersion(`1.0.0-rc09`),
+		semver.MustParseVersion(`1.0.0-dev.3`),
+	}
+	order.SortVersions(vs)
+	fmt.Println(vs)
+
+	rng := semver.MustParseVersionRange(`>=1.0.0-alpha <1.0.0`)
+	fmt.Println(order.Includes(rng, semver.MustParseVersion(`1.0.0-dev.3`)))
+	fmt.Println(rng.Includes(semver.MustParseVersion(`1.0.0-dev.3`)))
+	// Output:
+	// [1.0.0-dev.3 1.0.0-preview 1.0.0-rc09 1.0.0-rc9 1.0.0-rc10 1.0.0]
+	// false
+	// true
+}
@@ -0,0 +1,254 @@
+package semver
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// A Dialect identifies a version range syntax used by a package ecosystem
+type Dialect int
+
+const (
+	// Npm is the syntax of ParseVersionRange, e.g. ">=1.0.0 <2.0.0 || >=3.0.0"
+	Npm Dialect = iota
+
+	// Maven is the bracket syntax used by Maven, e.g. "[1.0.0,2.0.0),[3.0.0,)"
+	Maven
+
+	// NuGet is the interval syntax used by NuGet, e.g. "[1.0.0, 2.0.0)"
+	NuGet
+
+	// PEP440 is the version specifier syntax used by Python packaging, e.g. ">=1.0.0, <2.0.0"
+	PEP440
+
+	// Cargo is the requirement syntax used by Rust, e.g. ">=1.0.0, <2.0.0"
+	Cargo
+
+	// Composer is the constraint syntax used by PHP, e.g. ">=1.0.0 <2.0.0 || >=3.0.0"
+	Composer
+
+	// RubyGems is the requirement syntax used by Ruby, e.g. ">= 1.0.0, < 2.0.0"
+	RubyGems
+
+	// Terraform is the constraint syntax used by Terraform and Helm, e.g. ">= 1.0.0, < 2.0.0"
+	Terraform
+)
+
+var dialectNames = []string{`npm`, `maven`, `nuget`, `pep440`, `cargo`, `composer`, `rubygems`, `terraform`}
+
+// ParseDialect returns the dialect with the given name. The name is matched without regard to case.
+func ParseDialect(name string) (Dialect, error) {
+	for idx, n := range dialectNames {
+		if strings.EqualFold(n, name) {
+			return Dialect(idx), nil
+		}
+	}
+	return Npm, fmt.Errorf(`'%s' is not a known version range dialect`, name)
+}
+
+func (d Dialect) String() string {
+	if d >= 0 && int(d) < len(dialectNames) {
+		return dialectNames[d]
+	}
+	return fmt.Sprintf(`Dialect(%d)`, int(d))
+}
+
+// comparatorSyntax describes how a dialect expresses an interval as a list of comparators
+type comparatorSyntax struct {
+	gt, gtEq, lt, ltEq, eq string
+	and, or                string
+	all, none              string
+	version                func(Version) (string, error)
+}
+
+var pep440PrePattern = regexp.MustCompile(`\A(a|alpha|b|beta|c|rc|pre|preview|dev)\.?([0-9]*)\z`)
+
+var renderSyntaxes = map[Dialect]*comparatorSyntax{
+	PEP440:    {`>`, `>=`, `<`, `<=`, `==`, `, `, ``, ``, `<0`, pep440VersionString},
+	Cargo:     {`>`, `>=`, `<`, `<=`, `=`, `, `, ``, `*`, `<0.0.0`, semverVersionString},
+	Composer:  {`>`, `>=`, `<`, `<=`, ``, ` `, ` || `, `*`, `<0.0.0`, semverVersionString},
+	RubyGems:  {`> `, `>= `, `< `, `<= `, `= `, `, `, ``, `>= 0`, `< 0`, gemVersionString},
+	Terraform: {`> `, `>= `, `< `, `<= `, ``, `, `, ` || `, `>= 0.0.0`, `< 0.0.0`, semverVersionString},
+}
+
+// RenderVersionRange returns a string that expresses the same set of versions as the given range using
+// the syntax of the given dialect. Build metadata is not included in the result since it has no
+// impact on precedence.
+//
+// An error is returned when the range cannot be expressed in the dialect. This happens when the range is
+// a union of intervals and the dialect has no syntax for alternatives, or when an epoch, a NuGet revision,
+// or a pre-release cannot be expressed using the version syntax of the dialect.
+func RenderVersionRange(r VersionRange, d Dialect) (string, error) {
+	switch d {
+	case Npm:
+		return r.NormalizedString(), nil
+	case Maven:
+		return mavenRangeString(r)
+	case NuGet:
+		return NuGetVersionRangeString(r)
+	}
+
+	cs, ok := renderSyntaxes[d]
+	if !ok {
+		return ``, fmt.Errorf(`unable to render version ranges in unknown dialect %s`, d)
+	}
+	ivs := intervalsOf(r)
+	if d != PEP440 {
+		if err := checkNoEpoch(r, ivs, d.String()); err != nil {
+			return ``, err
+		}
+	}
+	if len(ivs) > 1 && cs.or == `` {
+		return ``, fmt.Errorf(`the range '%s' is a union of intervals which cannot be expressed in %s syntax`, r, d)
+	}
+	bld := bytes.NewBufferString(``)
+	for idx, iv := range ivs {
+		if idx > 0 {
+			bld.WriteString(cs.or)
+		}
+		if err := cs.writeInterval(iv, bld); err != nil {
+			return ``, fmt.Errorf(`the range '%s' cannot be expressed in %s syntax: %s`, r, d, err.Error())
+		}
+	}
+	return bld.String(), nil
+}
+
+func (cs *comparatorSyntax) writeInterval(iv interval, bld *bytes.Buffer) error {
+	switch {
+	case iv.isEmpty():
+		bld.WriteString(cs.none)
+		return nil
+	case iv.start == nil && iv.end == nil:
+		bld.WriteString(cs.all)
+		return nil
+	case iv.isExact():
+		return cs.writeComparator(cs.eq, iv.start, bld)
+	}
+	if iv.start != nil {
+		op := cs.gtEq
+		if iv.excludeStart {
+			op = cs.gt
+		}
+		if err := cs.writeComparator(op, iv.start, bld); err != nil {
+			return err
+		}
+		if iv.end != nil {
+			bld.WriteString(cs.and)
+		}
+	}
+	if iv.end != nil {
+		op := cs.ltEq
+		if iv.excludeEnd {
+			op = cs.lt
+		}
+		return cs.writeComparator(op, iv.end, bld)
+	}
+	return nil
+}
+
+func (cs *comparatorSyntax) writeComparator(op string, v Version, bld *bytes.Buffer) error {
+	s, err := cs.version(v)
+	if err != nil {
+		return err
+	}
+	bld.WriteString(op)
+	bld.WriteString(s)
+	return nil
+}
+
+func mavenRangeString(r VersionRange) (string, error) {
+	ivs := intervalsOf(r)
+	if err := checkNoEpoch(r, ivs, Maven.String()); err != nil {
+		return ``, err
+	}
+	bld := bytes.NewBufferString(``)
+	for idx, iv := range ivs {
+		if iv.isEmpty() {
+			return ``, fmt.Errorf(`the range '%s' does not include any versions and cannot be expressed in %s syntax`, r, Maven)
+		}
+		if idx > 0 {
+			bld.WriteString(`,`)
+		}
+		if iv.isExact() {
+			bld.WriteString(`[`)
+			bld.WriteString(NuGetVersionString(iv.start))
+			bld.WriteString(`]`)
+			continue
+		}
+		if iv.excludeStart || iv.start == nil {
+			bld.WriteString(`(`)
+		} else {
+			bld.WriteString(`[`)
+		}
+		if iv.start != nil {
+			bld.WriteString(NuGetVersionString(iv.start))
+		}
+		bld.WriteString(`,`)
+		if iv.end != nil {
+			bld.WriteString(NuGetVersionString(iv.end))
+		}
+		if iv.excludeEnd || iv.end == nil {
+			bld.WriteString(`)`)
+		} else {
+			bld.WriteString(`]`)
+		}
+	}
+	return bld.String(), nil
+}
+
+// semverVersionString returns the "major.minor.patch[-pre-release]" form of the given version that Cargo,
+// Composer, and Terraform share. An error is returned if the version has an epoch or a NuGet revision
+// since that form cannot express either.
+func semverVersionString(v Version) (string, error) {
+	if v.Epoch() != 0 {
+		return ``, fmt.Errorf(`the version %s has an epoch`, v)
+	}
+	if rev, ok := nugetRevision(v); ok {
+		return ``, fmt.Errorf(`the version %s has the NuGet revision %d`, v, rev)
+	}
+	s := fmt.Sprintf(`%d.%d.%d`, v.Major(), v.Minor(), v.Patch())
+	if v.IsStable() {
+		return s, nil
+	}
+	return s + `-` + v.PreRelease(), nil
+}
+
+func gemVersionString(v Version) (string, error) {
+	s := fmt.Sprintf(`%d.%d.%d`, v.Major(), v.Minor(), v.Patch())
+	if v.IsStable() {
+		return s, nil
+	}
+	return s + `.` + v.PreRelease(), nil
+}
+
+// pep440VersionString converts pre-releases such as "rc.1", "beta2", or "dev.3" into their PEP 440
+// counterparts "rc1", "b2", and ".dev3". The epoch is written using the same "N!" prefix as PEP 440.
+func pep440VersionString(v Version) (string, error) {
+	s := fmt.Sprintf(`%d.%d.%d`, v.Major(), v.Minor(), v.Patch())
+	if v.Epoch() != 0 {
+		s = fmt.Sprintf(`%d!%s`, v.Epoch(), s)
+	}
+	if v.IsStable() {
+		return s, nil
+	}
+	m := pep440PrePattern.FindStringSubmatch(strings.ToLower(v.PreRelease()))
+	if m == nil {
+		return ``, fmt.Errorf(`the pre-release '%s' has no PEP 440 counterpart`, v.PreRelease())
+	}
+	n := m[2]
+	if n == `` {
+		n = `0`
+	}
+	switch m[1] {
+	case `a`, `alpha`:
+		return s + `a` + n, nil
+	case `b`, `beta`:
+		return s + `b` + n, nil
+	case `dev`:
+		return s + `.dev` + n, nil
+	default:
+		return s + `rc` + n, nil
+	}
+}
@@ -0,0 +1,59 @@
+package semver_test
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleRenderVersionRange() {
+	rng := semver.MustParseVersionRange(`>=1.2.0-rc.1 <2.0.0`)
+	for _, d := range []semver.Dialect{semver.Npm, semver.Maven, semver.NuGet, semver.PEP440, semver.Cargo, semver.Composer, semver.RubyGems, semver.Terraform} {
+		s, err := semver.RenderVersionRange(rng, d)
+		if err == nil {
+			fmt.Printf("%s: %s\n", d, s)
+		} else {
+			fmt.Println(err)
+		}
+	}
+	// Output:
+	// npm: >=1.2.0-rc.1 <2.0.0
+	// maven: [1.2.0-rc.1,2.0.0)
+	// nuget: [1.2.0-rc.1, 2.0.0)
+	// pep440: >=1.2.0rc1, <2.0.0
+	// cargo: >=1.2.0-rc.1, <2.0.0
+	// composer: >=1.2.0-rc.1 <2.0.0
+	// rubygems: >= 1.2.0.rc.1, < 2.0.0
+	// terraform: >= 1.2.0-rc.1, < 2.0.0
+}
+
+func ExampleRenderVersionRange_union() {
+	rng := semver.MustParseVersionRange(`1.x || >=3.0.0`)
+	for _, d := range []semver.Dialect{semver.Maven, semver.Cargo} {
+		s, err := semver.RenderVersionRange(rng, d)
+		if err == nil {
+			fmt.Println(s)
+		} else {
+			fmt.Println(err)
+		}
+	}
+	// Output:
+	// [1.0.0,2.0.0),[3.0.0,)
+	// the range '1.x || >=3.0.0' is a union of intervals which cannot be expressed in cargo syntax
+}
+
+func ExampleRenderVersionRange_revision() {
+	rng, _ := semver.ParseNuGetVersionRange(`[1.2.3.4, 2.0)`)
+	for _, d := range []semver.Dialect{semver.NuGet, semver.Cargo, semver.Terraform} {
+		s, err := semver.RenderVersionRange(rng, d)
+		if err == nil {
+			fmt.Printf("%s: %s\n", d, s)
+		} else {
+			fmt.Println(err)
+		}
+	}
+	// Output:
+	// nuget: [1.2.3.4, 2.0.0)
+	// the range '[1.2.3.4, 2.0)' cannot be expressed in cargo syntax: the version 1.2.3+rev.4 has the NuGet revision 4
+	// the range '[1.2.3.4, 2.0)' cannot be expressed in terraform syntax: the version 1.2.3+rev.4 has the NuGet revision 4
+}
@@ -0,0 +1,316 @@
+package semver
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// A GemVersion represents a version as specified by RubyGems. See
+// https://guides.rubygems.org/patterns/#semantic-versioning for a description.
+//
+// A GemVersion may have an arbitrary number of segments. A segment that contains letters marks
+// the version as a pre-release, e.g. "1.0.a" or "2.1.0.rc1".
+type GemVersion interface {
+	fmt.Stringer
+
+	// Bump returns a new version where the next to last numeric segment is incremented and all
+	// segments following it are dropped, e.g. "5.3.1" becomes "5.4". This is the exclusive upper
+	// bound used by the pessimistic operator "~>".
+	Bump() GemVersion
+
+	// CompareTo compares the receiver to another version. Return zero if the versions are equal,
+	// a negative integer if the receiver is less than the given version, and a positive
+	// integer if the receiver is greater than the given version.
+	//
+	// Trailing zero segments are insignificant, so "1.0" and "1.0.0" are equal.
+	CompareTo(GemVersion) int
+
+	// IsPrerelease returns true when one of the segments contains letters
+	IsPrerelease() bool
+
+	// Release returns the version without its pre-release segments
+	Release() GemVersion
+
+	// Segments returns the segments of this version. Each segment is an int or a string
+	Segments() []interface{}
+
+	// ToVersion converts this version into a semantic version. The first three numeric segments
+	// become the major, minor, and patch numbers and the segments that follow the first string
+	// segment become the pre-release. An error is returned if the version has more than three
+	// significant numeric segments.
+	ToVersion() (Version, error)
+}
+
+// A GemRequirement represents a requirement as specified by RubyGems, i.e. a list of constraints
+// such as ">= 1.0, < 2" or "~> 2.2" that all must be satisfied.
+type GemRequirement interface {
+	fmt.Stringer
+
+	// IsSatisfiedBy returns true when the given version satisfies all constraints of this requirement
+	IsSatisfiedBy(v GemVersion) bool
+
+	// ToVersionRange converts this requirement into a VersionRange. An error is returned if one of
+	// the versions of the requirement cannot be converted into a semantic version.
+	ToVersionRange() (VersionRange, error)
+}
+
+type gemVersion struct {
+	str      string
+	segments []interface{}
+}
+
+type gemConstraint struct {
+	op string
+	v  *gemVersion
+}
+
+type gemRequirement struct {
+	constraints []gemConstraint
+}
+
+var gemVersionPattern = regexp.MustCompile(`\A[0-9]+(?:\.[0-9A-Za-z]+)*(?:-[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?\z`)
+var gemSegmentPattern = regexp.MustCompile(`[0-9]+|[A-Za-z]+`)
+var gemConstraintPattern = regexp.MustCompile(`\A\s*(=|!=|>=|<=|>|<|~>)?\s*(\S+)\s*\z`)
+
+// ParseGemVersion parses a string that conforms to the RubyGems version syntax. A dash in the string is
+// treated as a pre-release marker so "1.0-rc1" is the same version as "1.0.pre.rc1".
+func ParseGemVersion(str string) (GemVersion, error) {
+	s := strings.TrimSpace(str)
+	if s == `` {
+		s = `0`
+	}
+	if !gemVersionPattern.MatchString(s) {
+		return nil, fmt.Errorf(`the string '%s' does not represent a valid gem version`, str)
+	}
+	s = strings.Replace(s, `-`, `.pre.`, -1)
+	return &gemVersion{s, gemSegments(s)}, nil
+}
+
+// ParseGemRequirement parses a comma separated list of constraints. A constraint without an operator
+// means exact equality. The pessimistic operator "~>" allows the last given segment to change, so
+// "~> 2.2" means ">= 2.2, < 3.0" whereas "~> 2.2.0" means ">= 2.2.0, < 2.3".
+func ParseGemRequirement(str string) (GemRequirement, error) {
+	parts := strings.Split(str, `,`)
+	constraints := make([]gemConstraint, len(parts))
+	for idx, part := range parts {
+		m := gemConstraintPattern.FindStringSubmatch(part)
+		if m == nil {
+			return nil, fmt.Errorf(`'%s' is not a valid gem requirement`, str)
+		}
+		v, err := ParseGemVersion(m[2])
+		if err != nil {
+			return nil, err
+		}
+		op := m[1]
+		if op == `` {
+			op = `=`
+		}
+		constraints[idx] = gemConstraint{op, v.(*gemVersion)}
+	}
+	return &gemRequirement{constraints}, nil
+}
+
+func gemSegments(str string) []interface{} {
+	strs := gemSegmentPattern.FindAllString(str, -1)
+	segments := make([]interface{}, len(strs))
+	for idx, s := range strs {
+		if i, err := strconv.Atoi(s); err == nil {
+			segments[idx] = i
+		} else {
+			segments[idx] = s
+		}
+	}
+	return segments
+}
+
+func newGemVersion(segments []interface{}) *gemVersion {
+	bld := bytes.NewBufferString(``)
+	writeParts(segments, bld)
+	return &gemVersion{bld.String(), segments}
+}
+
+func (v *gemVersion) Bump() GemVersion {
+	segments := v.releaseSegments()
+	if len(segments) > 1 {
+		segments = segments[:len(segments)-1]
+	}
+	last := len(segments) - 1
+	segments[last] = segments[last].(int) + 1
+	return newGemVersion(segments)
+}
+
+func (v *gemVersion) CompareTo(other GemVersion) int {
+	ls := v.canonicalSegments()
+	rs := other.(*gemVersion).canonicalSegments()
+	top := len(ls)
+	if len(rs) > top {
+		top = len(rs)
+	}
+	for idx := 0; idx < top; idx++ {
+		var l, r interface{} = 0, 0
+		if idx < len(ls) {
+			l = ls[idx]
+		}
+		if idx < len(rs) {
+			r = rs[idx]
+		}
+		if l == r {
+			continue
+		}
+		li, lok := l.(int)
+		ri, rok := r.(int)
+		switch {
+		case lok && rok:
+			return li - ri
+		case lok:
+			return 1
+		case rok:
+			return -1
+		default:
+			return strings.Compare(l.(string), r.(string))
+		}
+	}
+	return 0
+}
+
+func (v *gemVersion) IsPrerelease() bool {
+	return len(v.releaseSegments()) < len(v.segments)
+}
+
+func (v *gemVersion) Release() GemVersion {
+	if v.IsPrerelease() {
+		return newGemVersion(v.releaseSegments())
+	}
+	return v
+}
+
+func (v *gemVersion) Segments() []interface{} {
+	return append(make([]interface{}, 0, len(v.segments)), v.segments...)
+}
+
+func (v *gemVersion) String() string {
+	return v.str
+}
+
+func (v *gemVersion) ToVersion() (Version, error) {
+	nbrs := trimZeros(v.releaseSegments())
+	if len(nbrs) > 3 {
+		return nil, fmt.Errorf(`the gem version '%s' has more than three numeric segments and cannot be represented as a semantic version`, v.str)
+	}
+	triplet := make([]int, 3)
+	for idx, n := range nbrs {
+		triplet[idx] = n.(int)
+	}
+	pre := bytes.NewBufferString(``)
+	writeParts(v.segments[len(v.releaseSegments()):], pre)
+	return NewVersion2(triplet[0], triplet[1], triplet[2], pre.String())
+}
+
+// canonicalSegments returns the segments with insignificant trailing zeros removed from both the
+// release and the pre-release part
+func (v *gemVersion) canonicalSegments() []interface{} {
+	rs := v.releaseSegments()
+	return append(trimZeros(rs), trimZeros(v.segments[len(rs):])...)
+}
+
+// releaseSegments returns the segments that precede the first string segment
+func (v *gemVersion) releaseSegments() []interface{} {
+	for idx, s := range v.segments {
+		if _, ok := s.(string); ok {
+			return append(make([]interface{}, 0, idx), v.segments[:idx]...)
+		}
+	}
+	return append(make([]interface{}, 0, len(v.segments)), v.segments...)
+}
+
+func trimZeros(segments []interface{}) []interface{} {
+	top := len(segments)
+	for top > 0 && segments[top-1] == 0 {
+		top--
+	}
+	return segments[:top]
+}
+
+func (r *gemRequirement) IsSatisfiedBy(v GemVersion) bool {
+	for _, c := range r.constraints {
+		if !c.isSatisfiedBy(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *gemRequirement) String() string {
+	bld := bytes.NewBufferString(``)
+	for idx, c := range r.constraints {
+		if idx > 0 {
+			bld.WriteString(`, `)
+		}
+		bld.WriteString(c.op)
+		bld.WriteString(` `)
+		bld.WriteString(c.v.str)
+	}
+	return bld.String()
+}
+
+func (r *gemRequirement) ToVersionRange() (VersionRange, error) {
+	ranges := []abstractRange{lowestLb}
+	for _, c := range r.constraints {
+		rngs, err := c.toRanges()
+		if err != nil {
+			return nil, err
+		}
+		ranges = andRanges(ranges, rngs)
+	}
+	return newVersionRange(r.String(), ranges), nil
+}
+
+func (c gemConstraint) isSatisfiedBy(v GemVersion) bool {
+	cmp := v.CompareTo(c.v)
+	switch c.op {
+	case `!=`:
+		return cmp != 0
+	case `>`:
+		return cmp > 0
+	case `<`:
+		return cmp < 0
+	case `>=`:
+		return cmp >= 0
+	case `<=`:
+		return cmp <= 0
+	case `~>`:
+		return cmp >= 0 && v.Release().CompareTo(c.v.Bump()) < 0
+	default:
+		return cmp == 0
+	}
+}
+
+func (c gemConstraint) toRanges() ([]abstractRange, error) {
+	v, err := c.v.ToVersion()
+	if err != nil {
+		return nil, err
+	}
+	switch c.op {
+	case `!=`:
+		return notEqualRanges(v), nil
+	case `>`:
+		return []abstractRange{&gtRange{simpleRange{v}}}, nil
+	case `<`:
+		return []abstractRange{&ltRange{simpleRange{v}}}, nil
+	case `>=`:
+		return []abstractRange{&gtEqRange{simpleRange{v}}}, nil
+	case `<=`:
+		return []abstractRange{&ltEqRange{simpleRange{v}}}, nil
+	case `~>`:
+		end, err := c.v.Bump().ToVersion()
+		if err != nil {
+			return nil, err
+		}
+		return []abstractRange{&startEndRange{&gtEqRange{simpleRange{v}}, &ltRange{simpleRange{end}}}}, nil
+	default:
+		return []abstractRange{&eqRange{simpleRange{v}}}, nil
+	}
+}
@@ -0,0 +1,55 @@
+package semver_test
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleParseGemVersion() {
+	a, _ := semver.ParseGemVersion(`1.0.0.rc1`)
+	b, _ := semver.ParseGemVersion(`1.0`)
+	c, _ := semver.ParseGemVersion(`1.0.0.0.1`)
+	fmt.Println(a.IsPrerelease(), a.CompareTo(b) < 0)
+	fmt.Println(b.CompareTo(c) < 0)
+	fmt.Println(c.Bump())
+	fmt.Println(a.ToVersion())
+	// Output:
+	// true true
+	// true
+	// 1.0.0.1
+	// 1.0.0-rc.1 <nil>
+}
+
+func ExampleParseGemRequirement() {
+	for _, s := range []string{`~> 2.2`, `~> 2.2.0`, `>= 1.0, < 2, != 1.5`} {
+		req, err := semver.ParseGemRequirement(s)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		rng, err := req.ToVersionRange()
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Println(rng.NormalizedString())
+	}
+	// Output:
+	// >=2.2.0 <3.0.0
+	// >=2.2.0 <2.3.0
+	// >=1.0.0 <1.5.0 || >1.5.0 <2.0.0
+}
+
+func ExampleGemRequirement_IsSatisfiedBy() {
+	req, _ := semver.ParseGemRequirement(`~> 2.2`)
+	for _, s := range []string{`2.2`, `2.9.1`, `3.0`, `3.0.a`} {
+		v, _ := semver.ParseGemVersion(s)
+		fmt.Println(s, req.IsSatisfiedBy(v))
+	}
+	// Output:
+	// 2.2 true
+	// 2.9.1 true
+	// 3.0 false
+	// 3.0.a false
+}
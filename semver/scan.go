@@ -0,0 +1,211 @@
+package semver
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Confidence tells how closely a scanned version conforms to Semantic Versioning
+type Confidence int
+
+const (
+	// Coerced means that the text had to be adjusted in order to form a version, e.g. "v1.2" became 1.2.0
+	Coerced Confidence = iota
+
+	// Strict means that the text, with the exception of an optional "v" prefix, is a valid semantic version
+	Strict
+)
+
+func (c Confidence) String() string {
+	if c == Strict {
+		return `strict`
+	}
+	return `coerced`
+}
+
+// A VersionMatch is a version found by ScanVersions
+type VersionMatch struct {
+	// Version is the version that the text represents
+	Version Version
+
+	// Text is the matched text
+	Text string
+
+	// Offset is the byte offset of the matched text in the scanned input
+	Offset int
+
+	// Confidence tells whether the text is a strict semantic version or if it was coerced
+	Confidence Confidence
+}
+
+// A ScanFilter decides if a match should be included in the result of ScanVersions. The input is the complete
+// scanned input which makes it possible to examine the context of the match.
+type ScanFilter func(input []byte, m *VersionMatch) bool
+
+var scanPattern = regexp.MustCompile(`v?([0-9]+)\.([0-9]+)(?:\.([0-9]+))?(?:\.([0-9]+))?(?:-(` + vParts + `))?(?:\+(` + vParts + `))?`)
+
+var scanNoise = map[string]bool{
+	`linux`: true, `darwin`: true, `macos`: true, `osx`: true, `windows`: true, `win32`: true, `win64`: true,
+	`freebsd`: true, `amd64`: true, `arm64`: true, `aarch64`: true, `x86_64`: true, `x64`: true, `x86`: true,
+	`i386`: true, `i686`: true, `386`: true, `arm`: true, `armv7`: true, `tar`: true, `gz`: true, `tgz`: true,
+	`zip`: true, `xz`: true, `bz2`: true, `zst`: true, `exe`: true, `msi`: true, `deb`: true, `rpm`: true,
+	`jar`: true, `whl`: true, `dmg`: true, `pkg`: true, `apk`: true, `sha256`: true, `txt`: true,
+}
+
+// StrictOnly is a ScanFilter that only accepts matches with Strict confidence
+func StrictOnly(_ []byte, m *VersionMatch) bool {
+	return m.Confidence == Strict
+}
+
+// PrecededBy returns a ScanFilter that only accepts matches where one of the given words, ignoring case,
+// occurs earlier on the same line as the match, e.g. PrecededBy("version").
+func PrecededBy(words ...string) ScanFilter {
+	return func(input []byte, m *VersionMatch) bool {
+		line := input[:m.Offset]
+		if nl := strings.LastIndexByte(string(line), '\n'); nl >= 0 {
+			line = line[nl+1:]
+		}
+		lower := strings.ToLower(string(line))
+		for _, w := range words {
+			if strings.Contains(lower, strings.ToLower(w)) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ScanVersions finds all version-like substrings in the given input and returns them in the order they
+// appear. A match is included when all the given filters accept it.
+//
+// A match consisting of three numbers optionally prefixed with "v" and followed by a valid pre-release and
+// build suffix is Strict. Text with two or four numbers, or with numbers that have leading zeros, is
+// Coerced into a version. Suffixes that consist of operating system, architecture, or file extension
+// names, such as the "-linux-amd64.tar.gz" in "foo-1.2.3-linux-amd64.tar.gz", are not considered part of
+// the version.
+func ScanVersions(r io.Reader, filters ...ScanFilter) ([]*VersionMatch, error) {
+	input, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*VersionMatch, 0)
+nextMatch:
+	for _, loc := range scanPattern.FindAllSubmatchIndex(input, -1) {
+		m := scanMatch(input, loc)
+		if m == nil {
+			continue
+		}
+		for _, f := range filters {
+			if !f(input, m) {
+				continue nextMatch
+			}
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+// Coerce returns the first version found in the given string using ScanVersions
+func Coerce(str string) (Version, error) {
+	ms, _ := ScanVersions(strings.NewReader(str))
+	if len(ms) == 0 {
+		return nil, fmt.Errorf(`the string '%s' does not contain a version`, str)
+	}
+	return ms[0].Version, nil
+}
+
+func scanMatch(input []byte, loc []int) *VersionMatch {
+	start := loc[0]
+	if start > 0 {
+		if c := input[start-1]; c >= '0' && c <= '9' || c == '.' {
+			return nil
+		}
+	}
+
+	group := func(n int) string {
+		if loc[2*n] < 0 {
+			return ``
+		}
+		return string(input[loc[2*n]:loc[2*n+1]])
+	}
+
+	end := loc[1]
+	pre, build := group(5), group(6)
+	if pre != `` || build != `` {
+		if cut := noiseStart(input[numbersEnd(loc):end]); cut >= 0 {
+			end = numbersEnd(loc) + cut
+			pre, build = splitQualifier(string(input[numbersEnd(loc):end]))
+		}
+	}
+
+	strict := loc[6] >= 0 && loc[8] < 0
+	nbrs := make([]int, 3)
+	for idx := 0; idx < 3; idx++ {
+		s := group(idx + 1)
+		if s == `` {
+			continue
+		}
+		if len(s) > 1 && s[0] == '0' {
+			strict = false
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return nil
+		}
+		nbrs[idx] = n
+	}
+	v, err := NewVersion3(nbrs[0], nbrs[1], nbrs[2], pre, build)
+	if err != nil {
+		// Drop a suffix that isn't valid in a semantic version
+		if v, err = NewVersion(nbrs[0], nbrs[1], nbrs[2]); err != nil {
+			return nil
+		}
+		end = numbersEnd(loc)
+		strict = false
+	}
+	m := &VersionMatch{Version: v, Text: string(input[start:end]), Offset: start, Confidence: Coerced}
+	if strict {
+		m.Confidence = Strict
+	}
+	return m
+}
+
+// noiseStart returns the offset of the separator that precedes the first operating system, architecture,
+// or file extension name in the given qualifier, or -1 when no such name is found
+func noiseStart(qualifier []byte) int {
+	sep := 0
+	for idx := 1; idx <= len(qualifier); idx++ {
+		if idx < len(qualifier) {
+			if c := qualifier[idx]; c != '.' && c != '-' && c != '+' {
+				continue
+			}
+		}
+		if scanNoise[strings.ToLower(string(qualifier[sep+1:idx]))] {
+			return sep
+		}
+		sep = idx
+	}
+	return -1
+}
+
+// splitQualifier splits a qualifier such as "-rc.1+build.5" into its pre-release and build parts
+func splitQualifier(qualifier string) (string, string) {
+	pre, build := qualifier, ``
+	if plus := strings.IndexByte(qualifier, '+'); plus >= 0 {
+		pre, build = qualifier[:plus], qualifier[plus+1:]
+	}
+	return strings.TrimPrefix(pre, `-`), build
+}
+
+// numbersEnd returns the end of the numeric part of a match
+func numbersEnd(loc []int) int {
+	for n := 4; n > 0; n-- {
+		if loc[2*n+1] >= 0 {
+			return loc[2*n+1]
+		}
+	}
+	return loc[1]
+}
@@ -0,0 +1,51 @@
+package semver_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleScanVersions() {
+	text := `go version go1.21.3 linux/amd64
+Downloading foo-v2.4.0-rc.1-linux-amd64.tar.gz (release 3.1.0-beta.2+exp.sha.5114f85)
+Python 3.10, build 01.02.03`
+	ms, _ := semver.ScanVersions(strings.NewReader(text))
+	for _, m := range ms {
+		fmt.Println(m.Offset, m.Text, m.Version, m.Confidence)
+	}
+	// Output:
+	// 13 1.21.3 1.21.3 strict
+	// 48 v2.4.0-rc.1 2.4.0-rc.1 strict
+	// 88 3.1.0-beta.2+exp.sha.5114f85 3.1.0-beta.2+exp.sha.5114f85 strict
+	// 125 3.10 3.10.0 coerced
+	// 137 01.02.03 1.2.3 coerced
+}
+
+func ExampleScanVersions_filters() {
+	text := `Server: 1.2.3
+Client version: v1.4.0
+Protocol version 2.1`
+	ms, _ := semver.ScanVersions(strings.NewReader(text), semver.StrictOnly, semver.PrecededBy(`version`))
+	for _, m := range ms {
+		fmt.Println(m.Text, m.Version)
+	}
+	// Output:
+	// v1.4.0 1.4.0
+}
+
+func ExampleCoerce() {
+	for _, s := range []string{`v1.2`, `release-2.0.1.7`, `none`} {
+		v, err := semver.Coerce(s)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Println(v)
+	}
+	// Output:
+	// 1.2.0
+	// 2.0.1
+	// the string 'none' does not contain a version
+}
@@ -0,0 +1,174 @@
+package semver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// A Scheme is a versioning scheme, i.e. a syntax for versions and version ranges along with the rules
+// used when comparing versions. All schemes map their versions onto Version and their ranges onto
+// VersionRange so functions such as VersionRange.Intersection work the same way regardless of scheme.
+// Use Scheme.Sort and Scheme.MaxSatisfying rather than SortVersions and MaxSatisfying to order versions
+// using the Compare function of the scheme.
+type Scheme interface {
+	// Name returns the name that the scheme is registered under, e.g. "semver" or "nuget"
+	Name() string
+
+	// Compare compares two versions of this scheme. Return zero if the versions are equal,
+	// a negative integer if a is less than b, and a positive integer if a is greater than b.
+	Compare(a, b Version) int
+
+	// MaxSatisfying returns the highest version according to Compare that is included in the given range,
+	// or nil if no such version exists
+	MaxSatisfying(vs []Version, r VersionRange) Version
+
+	// ParseVersion parses a version using the syntax of this scheme
+	ParseVersion(str string) (Version, error)
+
+	// ParseVersionRange parses a version range using the syntax of this scheme
+	ParseVersionRange(str string) (VersionRange, error)
+
+	// Sort sorts the given versions in ascending order according to Compare. The sort is stable.
+	Sort(vs []Version)
+
+	// VersionString returns the string representation of the given version using the syntax of this scheme
+	VersionString(v Version) string
+}
+
+type scheme struct {
+	name          string
+	parseVersion  func(string) (Version, error)
+	parseRange    func(string) (VersionRange, error)
+	versionString func(Version) string
+	compare       func(a, b Version) int
+}
+
+// SemVer is the built-in "semver" scheme which uses ParseVersion and ParseVersionRange
+var SemVer = NewScheme(`semver`, ParseVersion, ParseVersionRange, Version.String, Version.CompareTo)
+
+var schemesLock sync.RWMutex
+var schemes = map[string]Scheme{}
+
+func init() {
+	for _, s := range []Scheme{
+		SemVer,
+		NewScheme(`cargo`, ParseVersion, ParseCargoVersionRange, Version.String, Version.CompareTo),
+		NewScheme(`composer`, parsePrefixedVersion, ParseComposerVersionRange, Version.String, Version.CompareTo),
+		NewScheme(`nuget`, ParseNuGetVersion, ParseNuGetVersionRange, NuGetVersionString, compareNuGetVersions),
+		NewScheme(`rubygems`, parseGemVersion, parseGemVersionRange, func(v Version) string {
+			s, _ := gemVersionString(v)
+			return s
+		}, Version.CompareTo),
+		NewScheme(`terraform`, parsePrefixedVersion, ParseTerraformVersionRange, Version.String, Version.CompareTo),
+	} {
+		schemes[s.Name()] = s
+	}
+}
+
+// NewScheme creates a new Scheme from the given functions. The compare function is used by Scheme.Compare.
+// Pass Version.CompareTo for schemes that order versions according to Semantic Versioning.
+func NewScheme(name string, parseVersion func(string) (Version, error), parseRange func(string) (VersionRange, error), versionString func(Version) string, compare func(a, b Version) int) Scheme {
+	return &scheme{name, parseVersion, parseRange, versionString, compare}
+}
+
+// NewCalVerScheme creates a Scheme for calendar versions of the given format. Versions are converted using
+// CalVer.ToVersion and ranges use the syntax of ParseVersionRange, so ">=2026.1.0" is a valid range for
+// a format such as "YYYY.0M.MICRO". The scheme is named "calver:" followed by the format.
+func NewCalVerScheme(f CalVerFormat) Scheme {
+	return NewScheme(`calver:`+f.String(),
+		func(str string) (Version, error) {
+			cv, err := f.Parse(str)
+			if err != nil {
+				return nil, err
+			}
+			return cv.ToVersion()
+		},
+		ParseVersionRange,
+		func(v Version) string {
+			return calverString(f.(*calverFormat), v)
+		},
+		Version.CompareTo)
+}
+
+// RegisterScheme makes the given scheme available to LookupScheme. An error is returned if a scheme
+// with the same name is already registered.
+func RegisterScheme(s Scheme) error {
+	schemesLock.Lock()
+	defer schemesLock.Unlock()
+	if _, ok := schemes[s.Name()]; ok {
+		return fmt.Errorf(`a versioning scheme named '%s' is already registered`, s.Name())
+	}
+	schemes[s.Name()] = s
+	return nil
+}
+
+// LookupScheme returns the scheme registered under the given name
+func LookupScheme(name string) (Scheme, bool) {
+	schemesLock.RLock()
+	defer schemesLock.RUnlock()
+	s, ok := schemes[name]
+	return s, ok
+}
+
+// SchemeNames returns the sorted names of all registered schemes
+func SchemeNames() []string {
+	schemesLock.RLock()
+	defer schemesLock.RUnlock()
+	names := make([]string, 0, len(schemes))
+	for name := range schemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *scheme) Compare(a, b Version) int {
+	return s.compare(a, b)
+}
+
+func (s *scheme) MaxSatisfying(vs []Version, r VersionRange) Version {
+	return maxSatisfying(vs, r, s.compare)
+}
+
+func (s *scheme) Name() string {
+	return s.name
+}
+
+func (s *scheme) ParseVersion(str string) (Version, error) {
+	return s.parseVersion(str)
+}
+
+func (s *scheme) ParseVersionRange(str string) (VersionRange, error) {
+	return s.parseRange(str)
+}
+
+func (s *scheme) Sort(vs []Version) {
+	sortVersions(vs, s.compare)
+}
+
+func (s *scheme) VersionString(v Version) string {
+	return s.versionString(v)
+}
+
+// parsePrefixedVersion parses a semantic version that may be prefixed with "v"
+func parsePrefixedVersion(str string) (Version, error) {
+	return ParseVersion(strings.TrimPrefix(str, `v`))
+}
+
+func parseGemVersion(str string) (Version, error) {
+	gv, err := ParseGemVersion(str)
+	if err != nil {
+		return nil, err
+	}
+	return gv.ToVersion()
+}
+
+func parseGemVersionRange(str string) (VersionRange, error) {
+	req, err := ParseGemRequirement(str)
+	if err != nil {
+		return nil, err
+	}
+	return req.ToVersionRange()
+}
@@ -0,0 +1,78 @@
+package semver_test
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleLookupScheme() {
+	s, _ := semver.LookupScheme(`nuget`)
+	var vs []semver.Version
+	for _, str := range []string{`1.10`, `1.2.0-RC.1`, `1.2`, `2.0.0.0`} {
+		v, err := s.ParseVersion(str)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		vs = append(vs, v)
+	}
+	semver.SortVersions(vs)
+	for _, v := range vs {
+		fmt.Println(s.VersionString(v))
+	}
+	rng, _ := s.ParseVersionRange(`[1.1, 2.0)`)
+	fmt.Println(s.VersionString(semver.MaxSatisfying(vs, rng)))
+	// Output:
+	// 1.2.0-rc.1
+	// 1.2.0
+	// 1.10.0
+	// 2.0.0
+	// 1.10.0
+}
+
+func ExampleScheme_Compare() {
+	s, _ := semver.LookupScheme(`nuget`)
+	a, _ := s.ParseVersion(`1.2.3.4`)
+	b, _ := s.ParseVersion(`1.2.3.10`)
+	fmt.Println(a.CompareTo(b), s.Compare(a, b) < 0, s.Compare(b, a) > 0)
+	// Output:
+	// 0 true true
+}
+
+func ExampleScheme_Sort() {
+	s, _ := semver.LookupScheme(`nuget`)
+	var vs []semver.Version
+	for _, str := range []string{`1.2.3.10`, `1.2.3`, `1.2.3.4`} {
+		v, _ := s.ParseVersion(str)
+		vs = append(vs, v)
+	}
+	s.Sort(vs)
+	for _, v := range vs {
+		fmt.Println(s.VersionString(v))
+	}
+	rng, _ := s.ParseVersionRange(`[1.2.3, 1.2.4)`)
+	fmt.Println(s.VersionString(s.MaxSatisfying(vs, rng)))
+	// Output:
+	// 1.2.3
+	// 1.2.3.4
+	// 1.2.3.10
+	// 1.2.3.10
+}
+
+func ExampleNewCalVerScheme() {
+	s := semver.NewCalVerScheme(semver.MustParseCalVerFormat(`YYYY.0M.MICRO`))
+	a, _ := s.ParseVersion(`2026.04.1`)
+	b, _ := s.ParseVersion(`2026.10.0`)
+	fmt.Println(s.Name(), s.Compare(a, b) < 0, s.VersionString(a))
+	// Output:
+	// calver:YYYY.0M.MICRO true 2026.04.1
+}
+
+func ExampleRegisterScheme() {
+	fmt.Println(semver.RegisterScheme(semver.SemVer))
+	fmt.Println(semver.SchemeNames())
+	// Output:
+	// a versioning scheme named 'semver' is already registered
+	// [cargo composer nuget rubygems semver terraform]
+}
@@ -0,0 +1,44 @@
+package semver
+
+import "sort"
+
+// SortVersions sorts the given versions in ascending order of precedence. The sort is stable so
+// versions that only differ in build metadata retain their relative order.
+func SortVersions(vs []Version) {
+	sortVersions(vs, Version.CompareTo)
+}
+
+// MaxSatisfying returns the highest version in the given slice that is included in the given
+// range, or nil if no such version exists.
+func MaxSatisfying(vs []Version, r VersionRange) Version {
+	return maxSatisfying(vs, r, Version.CompareTo)
+}
+
+// MinSatisfying returns the lowest version in the given slice that is included in the given
+// range, or nil if no such version exists.
+func MinSatisfying(vs []Version, r VersionRange) Version {
+	var min Version
+	for _, v := range vs {
+		if r.Includes(v) && (min == nil || v.CompareTo(min) < 0) {
+			min = v
+		}
+	}
+	return min
+}
+
+// sortVersions sorts the given versions in ascending order using the given compare function
+func sortVersions(vs []Version, compare func(a, b Version) int) {
+	sort.SliceStable(vs, func(i, j int) bool { return compare(vs[i], vs[j]) < 0 })
+}
+
+// maxSatisfying returns the highest version according to the given compare function that is included in
+// the given range, or nil if no such version exists
+func maxSatisfying(vs []Version, r VersionRange, compare func(a, b Version) int) Version {
+	var max Version
+	for _, v := range vs {
+		if r.Includes(v) && (max == nil || compare(v, max) > 0) {
+			max = v
+		}
+	}
+	return max
+}
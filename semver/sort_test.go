@@ -0,0 +1,23 @@
+package semver_test
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleSortVersions() {
+	vs := []semver.Version{
+		semver.MustParseVersion(`1.10.0`),
+		semver.MustParseVersion(`1.2.0`),
+		semver.MustParseVersion(`1.2.0-rc.1`),
+	}
+	semver.SortVersions(vs)
+	fmt.Println(vs)
+	fmt.Println(semver.MaxSatisfying(vs, semver.MustParseVersionRange(`~1.2`)))
+	fmt.Println(semver.MinSatisfying(vs, semver.MustParseVersionRange(`>1.2.0-0`)))
+	// Output:
+	// [1.2.0-rc.1 1.2.0 1.10.0]
+	// 1.2.0
+	// 1.2.0-rc.1
+}
@@ -0,0 +1,124 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var terraformPartial = `v?(?:` + partial + `)`
+var terraformConstraintPattern = regexp.MustCompile(`\A(!=|=>|=<|>=|<=|~>|=|>|<|~|\^)?\s*` + terraformPartial + `\z`)
+var terraformHyphenPattern = regexp.MustCompile(`\A` + terraformPartial + `\s+-\s+` + terraformPartial + `\z`)
+var terraformAndSplit = regexp.MustCompile(`\s*,\s*|\s+`)
+var terraformOpWsPattern = regexp.MustCompile(`(!=|=>|=<|>=|<=|~>|[=<>~^])\s+`)
+
+// ParseTerraformVersionRange parses a version constraint that conforms to the syntax used by Terraform
+// "required_version" and by Helm charts, i.e. the constraint syntax of github.com/Masterminds/semver.
+//
+// Constraints separated by comma must all match and alternatives are separated by "||". Versions may be
+// prefixed with "v". A version without an operator is an exact match unless it contains a wildcard such
+// as "1.2.x" and missing numbers in partial versions are zero, so "> 1.2" compares against 1.2.0. The
+// pessimistic operator "~>" allows the last given number to change, i.e. "~> 1.2" means ">= 1.2.0, < 2.0.0"
+// whereas "~> 1.2.0" means ">= 1.2.0, < 1.3.0". The tilde and caret operators have the same meaning as in
+// Cargo.
+func ParseTerraformVersionRange(str string) (VersionRange, error) {
+	vr := strings.TrimSpace(str)
+	if vr == `` {
+		return nil, fmt.Errorf(`'%s' is not a valid version constraint`, str)
+	}
+
+	vr = terraformOpWsPattern.ReplaceAllString(vr, `$1`)
+	ranges := make([]abstractRange, 0)
+	for _, rangeStr := range orSplit.Split(vr, -1) {
+		if m := terraformHyphenPattern.FindStringSubmatch(rangeStr); m != nil {
+			start, err := createGtEqRange(m, 1)
+			if err != nil {
+				return nil, err
+			}
+			end, err := createLtEqRange(m, 6)
+			if err != nil {
+				return nil, err
+			}
+			if is := intersection(start, end); is != nil {
+				ranges = append(ranges, is)
+			}
+			continue
+		}
+
+		and := []abstractRange{lowestLb}
+		for _, constraint := range terraformAndSplit.Split(rangeStr, -1) {
+			rngs, err := createTerraformRanges(constraint)
+			if err != nil {
+				return nil, err
+			}
+			and = andRanges(and, rngs)
+		}
+		ranges = append(ranges, and...)
+	}
+	return newVersionRange(str, ranges), nil
+}
+
+func createTerraformRanges(constraint string) ([]abstractRange, error) {
+	m := terraformConstraintPattern.FindStringSubmatch(constraint)
+	if m == nil {
+		return nil, fmt.Errorf(`'%s' is not a valid version constraint`, constraint)
+	}
+
+	var rng abstractRange
+	var err error
+	switch m[1] {
+	case `~>`:
+		rng, err = createComposerTildeRange(m, 2)
+	case `~`:
+		rng, err = createTildeRange(m, 2)
+	case `^`:
+		rng, err = createZeroAwareCaretRange(m, 2)
+	default:
+		if hasWildcard(m, 2) {
+			if m[1] == `!=` {
+				var rngs []abstractRange
+				if rng, err = createXRange(m, 2); err == nil {
+					rngs = complementOf(rng)
+				}
+				return rngs, err
+			}
+			if m[1] == `` || m[1] == `=` {
+				rng, err = createXRange(m, 2)
+				break
+			}
+		}
+		v, ok, err := zeroFilledVersion(m, 2)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return []abstractRange{lowestLb}, nil
+		}
+		switch m[1] {
+		case `!=`:
+			return notEqualRanges(v), nil
+		case `>`:
+			rng = &gtRange{simpleRange{v}}
+		case `>=`, `=>`:
+			rng = &gtEqRange{simpleRange{v}}
+		case `<`:
+			rng = &ltRange{simpleRange{v}}
+		case `<=`, `=<`:
+			rng = &ltEqRange{simpleRange{v}}
+		default:
+			rng = &eqRange{simpleRange{v}}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []abstractRange{rng}, nil
+}
+
+// TerraformVersionRangeString returns the given range using the constraint syntax of Terraform and Helm,
+// e.g. ">= 1.2.0, < 2.0.0". A range that is a union of intervals is joined with "||", a syntax
+// that is understood by Helm but not by Terraform. An error is returned when the range cannot be
+// expressed in that syntax, e.g. when it includes a version with an epoch.
+func TerraformVersionRangeString(r VersionRange) (string, error) {
+	return RenderVersionRange(r, Terraform)
+}
@@ -0,0 +1,37 @@
+package semver_test
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleParseTerraformVersionRange() {
+	for _, s := range []string{`>= 1.2, < 2.0`, `~> 1.2.0`, `~> 1.2`, `v1.2.x`, `>= 1.0, != 1.3.5, < 2.0`, `^0.2.3 || 1.4.0 - 1.5`} {
+		rng, err := semver.ParseTerraformVersionRange(s)
+		if err == nil {
+			fmt.Println(rng.NormalizedString())
+		} else {
+			fmt.Println(err)
+		}
+	}
+	// Output:
+	// >=1.2.0 <2.0.0
+	// >=1.2.0 <1.3.0
+	// >=1.2.0 <2.0.0
+	// >=1.2.0 <1.3.0
+	// >=1.0.0 <1.3.5 || >1.3.5 <2.0.0
+	// >=0.2.3 <0.3.0 || >=1.4.0 <1.6.0
+}
+
+func ExampleTerraformVersionRangeString() {
+	for _, s := range []string{`^1.2.3`, `>=1.0.0 <1.3.5 || >1.3.5`, `1.2.3`, `*`, `1!1.0.0`} {
+		fmt.Println(semver.TerraformVersionRangeString(semver.MustParseVersionRange(s)))
+	}
+	// Output:
+	// >= 1.2.3, < 2.0.0 <nil>
+	// >= 1.0.0, < 1.3.5 || > 1.3.5 <nil>
+	// 1.2.3 <nil>
+	// >= 0.0.0 <nil>
+	//  the range '1!1.0.0' has a version with an epoch which cannot be expressed in terraform syntax
+}
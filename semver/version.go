@@ -27,9 +27,12 @@ type Version interface {
 	// In contrast to CompareTo, this method will include build prefixes in the comparison.
 	Equals(other Version) bool
 
-	// TripletEquals returns true if the major, minor, and patch numbers are equal.
+	// TripletEquals returns true if the epoch, major, minor, and patch numbers are equal.
 	TripletEquals(ov Version) bool
 
+	// Epoch returns the epoch. It is zero unless the version was explicitly given an epoch
+	Epoch() int
+
 	// IsStable returns true when the version has no pre-release suffix.
 	IsStable() bool
 
@@ -48,6 +51,16 @@ type Version interface {
 	// Build returns the pre-release suffix
 	Build() string
 
+	// NextMajor returns a copy of this version where the major number is
+	// incremented by one, the minor and patch numbers are reset to zero, and
+	// the pre-release and build suffixes are stripped off.
+	NextMajor() Version
+
+	// NextMinor returns a copy of this version where the minor number is
+	// incremented by one, the patch number is reset to zero, and the
+	// pre-release and build suffixes are stripped off.
+	NextMinor() Version
+
 	// NextPatch returns a copy of this version where the patch number is
 	// incremented by one and the pre-release and build suffixes are stripped
 	// off.
@@ -63,6 +76,7 @@ type Version interface {
 }
 
 type version struct {
+	epoch      int
 	major      int
 	minor      int
 	patch      int
@@ -84,9 +98,10 @@ var vNR = `(0|[1-9][0-9]*)`
 var vPRPartsPattern = regexp.MustCompile(`\A` + vPRParts + `\z`)
 var vPartsPattern = regexp.MustCompile(`\A` + vParts + `\z`)
 
-var Max Version = &version{math.MaxInt64, math.MaxInt64, math.MaxInt64, nil, nil}
-var Min = &version{0, 0, 0, minPrereleases, nil}
-var Zero = &version{0, 0, 0, nil, nil}
+var Max Version = &version{math.MaxInt64, math.MaxInt64, math.MaxInt64, math.MaxInt64, nil, nil}
+var Min = &version{0, 0, 0, 0, minPrereleases, nil}
+var Zero = &version{0, 0, 0, 0, nil, nil}
+var epochPattern = regexp.MustCompile(`\A` + vNR + `!`)
 var VersionPattern = regexp.MustCompile(`\A` + vNR + `\.` + vNR + `\.` + vNR + vQualifier + `\z`)
 
 func NewVersion(major, minor, patch int) (Version, error) {
@@ -98,7 +113,14 @@ func NewVersion2(major, minor, patch int, preRelease string) (Version, error) {
 }
 
 func NewVersion3(major, minor, patch int, preRelease string, build string) (Version, error) {
-	if major < 0 || minor < 0 || patch < 0 {
+	return NewVersion4(0, major, minor, patch, preRelease, build)
+}
+
+// NewVersion4 creates a version with an epoch. The epoch takes precedence over all other
+// components when versions are compared and is used when a project resets its version numbers,
+// e.g. 1!1.0.0 is greater than 9.0.0. A version without an explicit epoch has epoch 0.
+func NewVersion4(epoch, major, minor, patch int, preRelease string, build string) (Version, error) {
+	if epoch < 0 || major < 0 || minor < 0 || patch < 0 {
 		return nil, fmt.Errorf(`negative numbers not accepted in version`)
 	}
 	ps, err := splitParts(`pre-release`, preRelease, true)
@@ -109,7 +131,7 @@ func NewVersion3(major, minor, patch int, preRelease string, build string) (Vers
 	if err != nil {
 		return nil, err
 	}
-	return &version{major, minor, patch, ps, bs}, nil
+	return &version{epoch, major, minor, patch, ps, bs}, nil
 }
 
 func MustParseVersion(str string) Version {
@@ -120,12 +142,23 @@ func MustParseVersion(str string) Version {
 	return v
 }
 
+// ParseVersion parses a semantic version. The version may be prefixed with an epoch followed by
+// an exclamation mark, e.g. "1!2.0.0".
 func ParseVersion(str string) (version Version, err error) {
-	if group := VersionPattern.FindStringSubmatch(str); group != nil {
+	epoch := 0
+	vs := str
+	if m := epochPattern.FindStringSubmatch(str); m != nil {
+		epoch, err = strconv.Atoi(m[1])
+		if err != nil {
+			return nil, fmt.Errorf(`the string '%s' does not represent a valid semantic version`, str)
+		}
+		vs = str[len(m[0]):]
+	}
+	if group := VersionPattern.FindStringSubmatch(vs); group != nil {
 		major, _ := strconv.Atoi(group[1])
 		minor, _ := strconv.Atoi(group[2])
 		patch, _ := strconv.Atoi(group[3])
-		return NewVersion3(major, minor, patch, group[4], group[5])
+		return NewVersion4(epoch, major, minor, patch, group[4], group[5])
 	}
 	return nil, fmt.Errorf(`the string '%s' does not represent a valid semantic version`, str)
 }
@@ -140,14 +173,23 @@ func (v *version) Build() string {
 }
 
 func (v *version) CompareTo(other Version) int {
-	o := other.(*version)
+	return v.compareTo(other.(*version), nil)
+}
+
+func (v *version) compareTo(o *version, order *PreReleaseOrder) int {
+	if v.epoch != o.epoch {
+		if v.epoch < o.epoch {
+			return -1
+		}
+		return 1
+	}
 	cmp := v.major - o.major
 	if cmp == 0 {
 		cmp = v.minor - o.minor
 		if cmp == 0 {
 			cmp = v.patch - o.patch
 			if cmp == 0 {
-				cmp = comparePreReleases(v.preRelease, o.preRelease)
+				cmp = comparePreReleases(v.preRelease, o.preRelease, order)
 			}
 		}
 	}
@@ -163,6 +205,10 @@ func (v *version) IsStable() bool {
 	return v.preRelease == nil
 }
 
+func (v *version) Epoch() int {
+	return v.epoch
+}
+
 func (v *version) Major() int {
 	return v.major
 }
@@ -171,8 +217,16 @@ func (v *version) Minor() int {
 	return v.minor
 }
 
+func (v *version) NextMajor() Version {
+	return &version{v.epoch, v.major + 1, 0, 0, nil, nil}
+}
+
+func (v *version) NextMinor() Version {
+	return &version{v.epoch, v.major, v.minor + 1, 0, nil, nil}
+}
+
 func (v *version) NextPatch() Version {
-	return &version{v.major, v.minor, v.patch + 1, nil, nil}
+	return &version{v.epoch, v.major, v.minor, v.patch + 1, nil, nil}
 }
 
 func (v *version) Patch() int {
@@ -195,10 +249,13 @@ func (v *version) String() string {
 }
 
 func (v *version) ToStable() Version {
-	return &version{v.major, v.minor, v.patch, nil, v.build}
+	return &version{v.epoch, v.major, v.minor, v.patch, nil, v.build}
 }
 
 func (v *version) ToString(bld io.Writer) {
+	if v.epoch != 0 {
+		fmt.Fprintf(bld, `%d!`, v.epoch)
+	}
 	fmt.Fprintf(bld, `%d.%d.%d`, v.major, v.minor, v.patch)
 	if v.preRelease != nil {
 		bld.Write([]byte(`-`))
@@ -215,7 +272,11 @@ func (v *version) TripletEquals(other Version) bool {
 }
 
 func (v *version) tripletEquals(ov *version) bool {
-	return v.major == ov.major && v.minor == ov.minor && v.patch == ov.patch
+	return v.epoch == ov.epoch && v.major == ov.major && v.minor == ov.minor && v.patch == ov.patch
+}
+
+func (v *version) withEpoch(epoch int) *version {
+	return &version{epoch, v.major, v.minor, v.patch, v.preRelease, v.build}
 }
 
 func writeParts(parts []interface{}, bld io.Writer) {
@@ -229,7 +290,9 @@ func writeParts(parts []interface{}, bld io.Writer) {
 	}
 }
 
-func comparePreReleases(p1, p2 []interface{}) int {
+// comparePreReleases compares two pre-releases. The identifiers are compared according to the given
+// order or, when it is nil, according to the rules of Semantic Versioning.
+func comparePreReleases(p1, p2 []interface{}, order *PreReleaseOrder) int {
 	if p1 == nil {
 		if p2 == nil {
 			return 0
@@ -264,7 +327,12 @@ func comparePreReleases(p1, p2 []interface{}) int {
 			return 1
 		}
 
-		cmp := strings.Compare(v1.(string), v2.(string))
+		var cmp int
+		if order == nil {
+			cmp = strings.Compare(v1.(string), v2.(string))
+		} else {
+			cmp = order.compareIdentifiers(v1.(string), v2.(string))
+		}
 		if cmp != 0 {
 			return cmp
 		}
@@ -291,11 +359,18 @@ func equalSegments(a, b []interface{}) bool {
 	return true
 }
 
-func mungePart(part string) interface{} {
-	if i, err := strconv.ParseInt(part, 10, 64); err == nil {
-		return int(i)
+// mungePart returns the given identifier as an int if it is numeric and as a string otherwise. An error is
+// returned if a numeric identifier is too large for an int since it would otherwise be compared as a
+// string.
+func mungePart(tag, part string) (interface{}, error) {
+	i, err := strconv.Atoi(part)
+	if err == nil {
+		return i, nil
+	}
+	if err.(*strconv.NumError).Err == strconv.ErrRange {
+		return nil, fmt.Errorf(`the numeric identifier '%s' in %s is too large`, part, tag)
 	}
-	return part
+	return part, nil
 }
 
 func splitParts(tag, str string, stringToInt bool) ([]interface{}, error) {
@@ -315,7 +390,11 @@ func splitParts(tag, str string, stringToInt bool) ([]interface{}, error) {
 	result := make([]interface{}, len(parts))
 	for idx, sp := range parts {
 		if stringToInt {
-			result[idx] = mungePart(sp)
+			p, err := mungePart(tag, sp)
+			if err != nil {
+				return nil, err
+			}
+			result[idx] = p
 		} else {
 			result[idx] = sp
 		}
@@ -17,6 +17,17 @@ func ExampleParseVersion() {
 	// 1.0.0
 }
 
+func ExampleParseVersion_error() {
+	for _, s := range []string{`1.x`, `1!1.x`, `1.0.0-rc.99999999999999999999`} {
+		_, err := semver.ParseVersion(s)
+		fmt.Println(err)
+	}
+	// Output:
+	// the string '1.x' does not represent a valid semantic version
+	// the string '1!1.x' does not represent a valid semantic version
+	// the numeric identifier '99999999999999999999' in pre-release is too large
+}
+
 func ExampleVersion_NextPatch() {
 	v, err := semver.ParseVersion(`1.0.0`)
 	if err == nil {
@@ -30,6 +41,15 @@ func ExampleVersion_NextPatch() {
 	// 1.0.1
 }
 
+func ExampleVersion_NextMinor() {
+	v := semver.MustParseVersion(`1.2.3-rc.1`)
+	fmt.Println(v.NextMinor())
+	fmt.Println(v.NextMajor())
+	// Output:
+	// 1.3.0
+	// 2.0.0
+}
+
 func ExampleVersion_ToStable() {
 	v, err := semver.ParseVersion(`1.0.0-rc1`)
 	if err == nil {
@@ -42,3 +62,13 @@ func ExampleVersion_ToStable() {
 	// 1.0.0-rc1
 	// 1.0.0
 }
+
+func ExampleVersion_Epoch() {
+	a := semver.MustParseVersion(`9.2.0`)
+	b := semver.MustParseVersion(`1!1.0.0`)
+	fmt.Println(a.Epoch(), b.Epoch(), a.CompareTo(b) < 0)
+	fmt.Println(b.NextPatch())
+	// Output:
+	// 0 1 true
+	// 1!1.0.1
+}
@@ -12,6 +12,15 @@ import (
 // used for npm. See https://docs.npmjs.com/misc/semver for a full description
 type VersionRange interface {
 	fmt.Stringer
+	// Complement returns a range that includes all versions that the receiver does not include, or nil if
+	// the receiver includes all versions. The complement is computed from the bounds of the range, so the
+	// npm rule that a pre-release is only included when a bound has the same triplet is not reflected.
+	Complement() VersionRange
+
+	// Difference returns a range that includes all versions that the receiver includes and the given
+	// range does not include, or nil if no such versions exist
+	Difference(other VersionRange) VersionRange
+
 	// EndVersion returns the ending version in the range if that is possible to determine, or nil otherwise
 	EndVersion() Version
 
@@ -112,7 +121,9 @@ var qualifier = `(?:-(` + parts + `))?(?:\+(` + parts + `))?`
 
 var partial = xr + `(?:\.` + xr + `(?:\.` + xr + qualifier + `)?)?`
 
-var simple = `([<>=~^]|<=|>=|~>|~=)?(?:` + partial + `)`
+var epoch = `(?:(` + nr + `)!)?`
+
+var simple = `([<>=~^]|<=|>=|~>|~=)?` + epoch + `(?:` + partial + `)`
 var simplePattern = regexp.MustCompile(`\A` + simple + `\z`)
 
 var orSplit = regexp.MustCompile(`\s*\|\|\s*`)
@@ -120,7 +131,7 @@ var simpleSplit = regexp.MustCompile(`\s+`)
 
 var opWsPattern = regexp.MustCompile(`([><=~^])(?:\s+|\s*v)`)
 
-var hyphen = `(?:` + partial + `)\s+-\s+(?:` + partial + `)`
+var hyphen = epoch + `(?:` + partial + `)\s+-\s+` + epoch + `(?:` + partial + `)`
 var hyphenPattern = regexp.MustCompile(`\A` + hyphen + `\z`)
 
 var highestLb = &gtRange{simpleRange{Max}}
@@ -176,15 +187,23 @@ func ParseVersionRange(vr string) (result VersionRange, err error) {
 		}
 
 		if m := hyphenPattern.FindStringSubmatch(rangeStr); m != nil {
-			e1, err := createGtEqRange(m, 1)
+			e1, err := createGtEqRange(m, 2)
 			if err != nil {
 				return nil, err
 			}
-			e2, err := createGtEqRange(m, 6)
+			if e1, err = withEpoch(e1, m[1]); err != nil {
+				return nil, err
+			}
+			e2, err := createLtEqRange(m, 8)
 			if err != nil {
 				return nil, err
 			}
-			ranges = append(ranges, intersection(e1, e2))
+			if e2, err = withEpoch(e2, m[7]); err != nil {
+				return nil, err
+			}
+			if is := intersection(e1, e2); is != nil {
+				ranges = append(ranges, is)
+			}
 			continue
 		}
 
@@ -198,19 +217,22 @@ func ParseVersionRange(vr string) (result VersionRange, err error) {
 			var err error
 			switch m[1] {
 			case `~`, `~>`:
-				rng, err = createTildeRange(m, 2)
+				rng, err = createTildeRange(m, 3)
 			case `^`:
-				rng, err = createCaretRange(m, 2)
+				rng, err = createCaretRange(m, 3)
 			case `>`:
-				rng, err = createGtRange(m, 2)
+				rng, err = createGtRange(m, 3)
 			case `>=`:
-				rng, err = createGtEqRange(m, 2)
+				rng, err = createGtEqRange(m, 3)
 			case `<`:
-				rng, err = createLtRange(m, 2)
+				rng, err = createLtRange(m, 3)
 			case `<=`:
-				rng, err = createLtEqRange(m, 2)
+				rng, err = createLtEqRange(m, 3)
 			default:
-				rng, err = createXRange(m, 2)
+				rng, err = createXRange(m, 3)
+			}
+			if err == nil {
+				rng, err = withEpoch(rng, m[2])
 			}
 			if err != nil {
 				return nil, err
@@ -228,6 +250,25 @@ func ParseVersionRange(vr string) (result VersionRange, err error) {
 	return newVersionRange(vr, ranges), nil
 }
 
+func (r *versionRange) Complement() VersionRange {
+	result := []abstractRange{lowestLb}
+	for _, ar := range r.ranges {
+		result = andRanges(result, complementOf(ar))
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return newVersionRange(``, result)
+}
+
+func (r *versionRange) Difference(other VersionRange) VersionRange {
+	c := other.Complement()
+	if c == nil {
+		return nil
+	}
+	return r.Intersection(c)
+}
+
 func (r *versionRange) EndVersion() Version {
 	if len(r.ranges) == 1 {
 		return r.ranges[0].end()
@@ -260,6 +301,18 @@ func (r *versionRange) Includes(v Version) bool {
 	return false
 }
 
+// includesUsing is like Includes but compares versions using the given function
+func (r *versionRange) includesUsing(v Version, cmp func(a, b Version) int) bool {
+	if v != nil {
+		for _, ar := range r.ranges {
+			if isWithin(ar, v, cmp) && (v.IsStable() || ar.testPrerelease(v)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (r *versionRange) Intersection(other VersionRange) VersionRange {
 	if other != nil {
 		or := other.(*versionRange)
@@ -308,7 +361,10 @@ func (r *versionRange) IsExcludeStart() bool {
 }
 
 func (r *versionRange) Merge(or VersionRange) VersionRange {
-	return newVersionRange(``, append(r.ranges, or.(*versionRange).ranges...))
+	// Copy the ranges so that appending never writes to the backing array of the receiver
+	ranges := make([]abstractRange, 0, len(r.ranges)+len(or.(*versionRange).ranges))
+	ranges = append(ranges, r.ranges...)
+	return newVersionRange(``, append(ranges, or.(*versionRange).ranges...))
 }
 
 func (r *versionRange) NormalizedString() string {
@@ -429,7 +485,7 @@ func createGtRange(rxGroup []string, startInMatcher int) (abstractRange, error)
 		return nil, err
 	}
 	if !ok {
-		return &gtEqRange{simpleRange{&version{major + 1, 0, 0, nil, nil}}}, nil
+		return &gtEqRange{simpleRange{&version{0, major + 1, 0, 0, nil, nil}}}, nil
 	}
 	startInMatcher++
 	patch, ok, err := xDigit(rxGroup[startInMatcher])
@@ -437,7 +493,7 @@ func createGtRange(rxGroup []string, startInMatcher int) (abstractRange, error)
 		return nil, err
 	}
 	if !ok {
-		return &gtEqRange{simpleRange{&version{major, minor + 1, 0, nil, nil}}}, nil
+		return &gtEqRange{simpleRange{&version{0, major, minor + 1, 0, nil, nil}}}, nil
 	}
 	startInMatcher++
 	preRelease := rxGroup[startInMatcher]
@@ -464,7 +520,7 @@ func createLtEqRange(rxGroup []string, startInMatcher int) (abstractRange, error
 		return nil, err
 	}
 	if !ok {
-		return &ltRange{simpleRange{&version{major + 1, 0, 0, nil, nil}}}, nil
+		return &ltRange{simpleRange{&version{0, major + 1, 0, 0, nil, nil}}}, nil
 	}
 	startInMatcher++
 	patch, ok, err := xDigit(rxGroup[startInMatcher])
@@ -472,7 +528,7 @@ func createLtEqRange(rxGroup []string, startInMatcher int) (abstractRange, error
 		return nil, err
 	}
 	if !ok {
-		return &ltRange{simpleRange{&version{major, minor + 1, 0, nil, nil}}}, nil
+		return &ltRange{simpleRange{&version{0, major, minor + 1, 0, nil, nil}}}, nil
 	}
 	startInMatcher++
 	preRelease := rxGroup[startInMatcher]
@@ -558,8 +614,8 @@ func allowPatchUpdates(rxGroup []string, startInMatcher int, tildeOrCaret bool)
 	}
 	if !ok {
 		return &startEndRange{
-			&gtEqRange{simpleRange{&version{major, 0, 0, nil, nil}}},
-			&ltRange{simpleRange{&version{major + 1, 0, 0, nil, nil}}}}, nil
+			&gtEqRange{simpleRange{&version{0, major, 0, 0, nil, nil}}},
+			&ltRange{simpleRange{&version{0, major + 1, 0, 0, nil, nil}}}}, nil
 	}
 	startInMatcher++
 	patch, ok, err := xDigit(rxGroup[startInMatcher])
@@ -568,8 +624,8 @@ func allowPatchUpdates(rxGroup []string, startInMatcher int, tildeOrCaret bool)
 	}
 	if !ok {
 		return &startEndRange{
-			&gtEqRange{simpleRange{&version{major, minor, 0, nil, nil}}},
-			&ltRange{simpleRange{&version{major, minor + 1, 0, nil, nil}}}}, nil
+			&gtEqRange{simpleRange{&version{0, major, minor, 0, nil, nil}}},
+			&ltRange{simpleRange{&version{0, major, minor + 1, 0, nil, nil}}}}, nil
 	}
 	startInMatcher++
 	preRelease := rxGroup[startInMatcher]
@@ -582,7 +638,7 @@ func allowPatchUpdates(rxGroup []string, startInMatcher int, tildeOrCaret bool)
 	if tildeOrCaret {
 		return &startEndRange{
 			&gtEqRange{simpleRange{v}},
-			&ltRange{simpleRange{&version{major, minor + 1, 0, nil, nil}}}}, nil
+			&ltRange{simpleRange{&version{0, major, minor + 1, 0, nil, nil}}}}, nil
 	}
 	return &eqRange{simpleRange{v}}, nil
 }
@@ -610,7 +666,39 @@ func allowMinorUpdates(rxGroup []string, major int, startInMatcher int) (abstrac
 	}
 	return &startEndRange{
 		&gtEqRange{simpleRange{v}},
-		&ltRange{simpleRange{&version{major + 1, 0, 0, nil, nil}}}}, nil
+		&ltRange{simpleRange{&version{0, major + 1, 0, 0, nil, nil}}}}, nil
+}
+
+// withEpoch returns a copy of the given range where all versions have the given epoch. The range is
+// returned unchanged when the epoch is empty or zero. A range that matches all versions is restricted
+// to the versions of the epoch.
+func withEpoch(ar abstractRange, epochStr string) (abstractRange, error) {
+	epoch, ok, err := xDigit(epochStr)
+	if err != nil || !ok || epoch == 0 {
+		return ar, err
+	}
+	switch r := ar.(type) {
+	case *startEndRange:
+		s, _ := withEpoch(r.startCompare, epochStr)
+		e, _ := withEpoch(r.endCompare, epochStr)
+		return &startEndRange{s, e}, nil
+	case *eqRange:
+		return &eqRange{simpleRange{r.Version.(*version).withEpoch(epoch)}}, nil
+	case *gtRange:
+		return &gtRange{simpleRange{r.Version.(*version).withEpoch(epoch)}}, nil
+	case *gtEqRange:
+		if r == lowestLb {
+			return &startEndRange{
+				&gtEqRange{simpleRange{Min.withEpoch(epoch)}},
+				&ltRange{simpleRange{Min.withEpoch(epoch + 1)}}}, nil
+		}
+		return &gtEqRange{simpleRange{r.Version.(*version).withEpoch(epoch)}}, nil
+	case *ltRange:
+		return &ltRange{simpleRange{r.Version.(*version).withEpoch(epoch)}}, nil
+	case *ltEqRange:
+		return &ltEqRange{simpleRange{r.Version.(*version).withEpoch(epoch)}}, nil
+	}
+	return ar, nil
 }
 
 func xDigit(str string) (int, bool, error) {
@@ -623,6 +711,17 @@ func xDigit(str string) (int, bool, error) {
 	return 0, false, fmt.Errorf(`illegal version triplet`)
 }
 
+// isWithin returns true if the given version is between the start and end of the given range when
+// compared using the given function
+func isWithin(ar abstractRange, v Version, cmp func(a, b Version) int) bool {
+	c := cmp(ar.start(), v)
+	if c > 0 || c == 0 && ar.isExcludeStart() {
+		return false
+	}
+	c = cmp(v, ar.end())
+	return c < 0 || c == 0 && !ar.isExcludeEnd()
+}
+
 func isOverlap(ra, rb abstractRange) bool {
 	cmp := ra.start().CompareTo(rb.end())
 	if cmp < 0 || cmp == 0 && !(ra.isExcludeStart() || rb.isExcludeEnd()) {
@@ -691,7 +790,7 @@ func intersection(ra, rb abstractRange) abstractRange {
 		end = rb
 	}
 
-	if !end.isUpperBound() {
+	if start == end || !end.isUpperBound() {
 		return start
 	}
 
@@ -703,19 +802,19 @@ func intersection(ra, rb abstractRange) abstractRange {
 }
 
 func fromTo(ra, rb abstractRange) abstractRange {
-	var startR abstractRange
-	if ra.isExcludeStart() {
-		startR = &gtRange{simpleRange{ra.start()}}
-	} else {
-		startR = &gtEqRange{simpleRange{ra.start()}}
+	return mergedRange(ra.start(), ra.isExcludeStart(), rb.end(), rb.isExcludeEnd())
+}
+
+// mergedRange is like newBoundedRange but treats a start of Min and an end of Max as unbounded, so that a
+// merge never produces a range whose string contains those sentinel versions
+func mergedRange(start Version, excludeStart bool, end Version, excludeEnd bool) abstractRange {
+	if start.Equals(Min) {
+		start = nil
 	}
-	var endR abstractRange
-	if rb.isExcludeEnd() {
-		endR = &ltRange{simpleRange{rb.end()}}
-	} else {
-		endR = &ltEqRange{simpleRange{rb.end()}}
+	if end.Equals(Max) {
+		end = nil
 	}
-	return &startEndRange{startR, endR}
+	return newBoundedRange(start, excludeStart, end, excludeEnd)
 }
 
 func union(ra, rb abstractRange) abstractRange {
@@ -748,19 +847,7 @@ func union(ra, rb abstractRange) abstractRange {
 			excludeEnd = ra.isExcludeEnd() && rb.isExcludeEnd()
 		}
 
-		var startR abstractRange
-		if excludeStart {
-			startR = &gtRange{simpleRange{start}}
-		} else {
-			startR = &gtEqRange{simpleRange{start}}
-		}
-		var endR abstractRange
-		if excludeEnd {
-			endR = &ltRange{simpleRange{end}}
-		} else {
-			endR = &ltEqRange{simpleRange{end}}
-		}
-		return &startEndRange{startR, endR}
+		return mergedRange(start, excludeStart, end, excludeEnd)
 	}
 	if ra.isExcludeStart() && rb.isExcludeStart() && ra.start().CompareTo(rb.start()) == 0 {
 		return fromTo(ra, rb)
@@ -1058,6 +1145,10 @@ func (r *ltRange) isBelow(v Version) bool {
 	return r.CompareTo(v) <= 0
 }
 
+func (r *ltRange) isExcludeEnd() bool {
+	return true
+}
+
 func (r *ltRange) isUpperBound() bool {
 	return true
 }
@@ -35,3 +35,92 @@ func ExampleMatchAll() {
 	// true
 	// true
 }
+
+func ExampleParseVersionRange_epoch() {
+	rng, err := semver.ParseVersionRange(`^1!1.2.0`)
+	if err == nil {
+		fmt.Println(rng.NormalizedString())
+		fmt.Println(rng.Includes(semver.MustParseVersion(`1!1.5.0`)))
+		fmt.Println(rng.Includes(semver.MustParseVersion(`1.5.0`)))
+		fmt.Println(semver.RenderVersionRange(rng, semver.PEP440))
+	} else {
+		fmt.Println(err)
+	}
+	// Output:
+	// >=1!1.2.0 <1!2.0.0
+	// true
+	// false
+	// >=1!1.2.0, <1!2.0.0 <nil>
+}
+
+func ExampleVersionRange_Complement() {
+	for _, s := range []string{`^1.2.0`, `<1.0.0 || >=2.0.0`, `1.2.3`, `*`} {
+		c := semver.MustParseVersionRange(s).Complement()
+		if c == nil {
+			fmt.Println(s, `=> nothing`)
+			continue
+		}
+		fmt.Println(s, `=>`, c.NormalizedString())
+	}
+	// Output:
+	// ^1.2.0 => <1.2.0 || >=2.0.0
+	// <1.0.0 || >=2.0.0 => >=1.0.0 <2.0.0
+	// 1.2.3 => <1.2.3 || >1.2.3
+	// * => nothing
+}
+
+func ExampleVersionRange_Difference() {
+	a := semver.MustParseVersionRange(`>=1.0.0 <3.0.0`)
+	fmt.Println(a.Difference(semver.MustParseVersionRange(`^2.0.0`)).NormalizedString())
+	fmt.Println(a.Difference(semver.MustParseVersionRange(`*`)) == nil)
+	// Output:
+	// >=1.0.0 <2.0.0
+	// true
+}
+
+func ExampleVersionRange_IsExcludeEnd() {
+	for _, s := range []string{`<2.0.0`, `<=2.0.0`, `<2.0.0 || <3.0.0`} {
+		rng := semver.MustParseVersionRange(s)
+		fmt.Println(s, `=>`, rng.NormalizedString(), rng.IsExcludeEnd(), rng.Includes(semver.MustParseVersion(`2.0.0`)), rng.Includes(semver.MustParseVersion(`3.0.0`)))
+	}
+	// Output:
+	// <2.0.0 => <2.0.0 true false false
+	// <=2.0.0 => <=2.0.0 false true false
+	// <2.0.0 || <3.0.0 => <3.0.0 true true false
+}
+
+func ExampleVersionRange_NormalizedString() {
+	for _, s := range []string{`<1.0.0 || >=1.0.0`, `<=1.0.0 || >1.0.0`, `>=2.0.0 || <2.0.0 >1.0.0`} {
+		fmt.Println(s, `=>`, semver.MustParseVersionRange(s).NormalizedString())
+	}
+	// Output:
+	// <1.0.0 || >=1.0.0 => >=0.0.0-
+	// <=1.0.0 || >1.0.0 => >=0.0.0-
+	// >=2.0.0 || <2.0.0 >1.0.0 => >1.0.0
+}
+
+func ExampleParseVersionRange_hyphen() {
+	for _, s := range []string{`1.0.0 - 2.0.0`, `1.2 - 2.3`, `2.0.0 - 1.0.0`} {
+		rng := semver.MustParseVersionRange(s)
+		fmt.Println(s, `=>`, rng.NormalizedString(), rng.Includes(semver.MustParseVersion(`1.5.0`)))
+	}
+	// Output:
+	// 1.0.0 - 2.0.0 => >=1.0.0 <=2.0.0 true
+	// 1.2 - 2.3 => >=1.2.0 <2.4.0 true
+	// 2.0.0 - 1.0.0 => <0.0.0- false
+}
+
+func ExampleVersionRange_Merge() {
+	a := semver.MustParseVersionRange(`^1.0.0 || ^3.0.0`)
+	fmt.Println(a.NormalizedString())
+	fmt.Println(a.Merge(semver.MustParseVersionRange(`^2.0.0`)).NormalizedString())
+	fmt.Println(a.Merge(semver.MustParseVersionRange(`5.0.0`)).NormalizedString())
+	fmt.Println(a.Merge(semver.MustParseVersionRange(`*`)).NormalizedString())
+	fmt.Println(a.NormalizedString())
+	// Output:
+	// >=1.0.0 <2.0.0 || >=3.0.0 <4.0.0
+	// >=1.0.0 <4.0.0
+	// >=1.0.0 <2.0.0 || >=3.0.0 <4.0.0 || 5.0.0
+	// >=0.0.0-
+	// >=1.0.0 <2.0.0 || >=3.0.0 <4.0.0
+}
@@ -0,0 +1,175 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Status is the status of a published version
+type Status int
+
+const (
+	// Active is the status of a version that can be selected
+	Active Status = iota
+
+	// Deprecated is the status of a version that is discouraged but still works
+	Deprecated
+
+	// Yanked is the status of a version that has been withdrawn
+	Yanked
+)
+
+var statusNames = []string{`active`, `deprecated`, `yanked`}
+
+// VersionSet is a set of published versions where each version has a status. Deprecated and yanked
+// versions are skipped by the selection functions unless they are pinned exactly by a range or, as in
+// Cargo, already locked.
+type VersionSet interface {
+	// Add adds versions with the status Active. Versions that are already in the set are not changed.
+	Add(vs ...Version)
+
+	// Available returns the versions with the status Active in ascending order
+	Available() []Version
+
+	// MaxSatisfying returns the highest active version that is included in the given range, or nil if
+	// no such version exists. A range that only includes one version, e.g. "=1.2.3", selects the version
+	// of the set with that precedence regardless of its status and build metadata.
+	MaxSatisfying(r VersionRange) Version
+
+	// Select is like MaxSatisfying but returns the locked version, regardless of its status, if a version
+	// with the same precedence is in the set and the locked version is still included in the range. The
+	// locked version may be nil.
+	Select(r VersionRange, locked Version) Version
+
+	// SetStatus changes the status of a version and records the reason for the change. An error is
+	// returned if the version is not in the set.
+	SetStatus(v Version, s Status, reason string) error
+
+	// Status returns the status of a version along with the reason given when it was set. The status of
+	// a version that is not in the set is Active.
+	Status(v Version) (Status, string)
+
+	// Versions returns all versions in ascending order
+	Versions() []Version
+}
+
+type versionStatus struct {
+	status Status
+	reason string
+}
+
+type versionSet struct {
+	versions []Version
+	statuses map[string]*versionStatus
+}
+
+// NewVersionSet returns a set that contains the given versions with the status Active
+func NewVersionSet(vs ...Version) VersionSet {
+	set := &versionSet{statuses: make(map[string]*versionStatus, len(vs))}
+	set.Add(vs...)
+	return set
+}
+
+// ParseStatus returns the status with the given name. The name is matched without regard to case.
+func ParseStatus(str string) (Status, error) {
+	for i, n := range statusNames {
+		if strings.EqualFold(n, str) {
+			return Status(i), nil
+		}
+	}
+	return Active, fmt.Errorf(`'%s' is not a valid version status`, str)
+}
+
+func (s Status) String() string {
+	if s >= 0 && int(s) < len(statusNames) {
+		return statusNames[s]
+	}
+	return fmt.Sprintf(`Status(%d)`, int(s))
+}
+
+func (set *versionSet) Add(vs ...Version) {
+	for _, v := range vs {
+		key := v.String()
+		if _, ok := set.statuses[key]; !ok {
+			set.statuses[key] = &versionStatus{Active, ``}
+			set.versions = append(set.versions, v)
+		}
+	}
+	SortVersions(set.versions)
+}
+
+func (set *versionSet) Available() []Version {
+	result := make([]Version, 0, len(set.versions))
+	for _, v := range set.versions {
+		if set.statuses[v.String()].status == Active {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func (set *versionSet) MaxSatisfying(r VersionRange) Version {
+	if pv := pinnedVersion(r); pv != nil {
+		return set.find(pv)
+	}
+	return MaxSatisfying(set.Available(), r)
+}
+
+func (set *versionSet) Select(r VersionRange, locked Version) Version {
+	if locked != nil && r.Includes(locked) {
+		if v := set.find(locked); v != nil {
+			return v
+		}
+	}
+	return set.MaxSatisfying(r)
+}
+
+func (set *versionSet) SetStatus(v Version, s Status, reason string) error {
+	vs, ok := set.statuses[v.String()]
+	if !ok {
+		return fmt.Errorf(`version %s is not in the set`, v)
+	}
+	vs.status = s
+	vs.reason = reason
+	return nil
+}
+
+func (set *versionSet) Status(v Version) (Status, string) {
+	if vs, ok := set.statuses[v.String()]; ok {
+		return vs.status, vs.reason
+	}
+	return Active, ``
+}
+
+func (set *versionSet) Versions() []Version {
+	return append([]Version{}, set.versions...)
+}
+
+// find returns the version in the set that has the same precedence as the given version, or nil if there
+// is no such version. When several versions only differ in build metadata, the one that is equal to the
+// given version is preferred, and otherwise the one with the highest build metadata.
+func (set *versionSet) find(v Version) Version {
+	var found Version
+	for _, sv := range set.versions {
+		if sv.CompareTo(v) != 0 {
+			continue
+		}
+		if sv.Equals(v) {
+			return sv
+		}
+		if found == nil || BuildOrder(sv, found) > 0 {
+			found = sv
+		}
+	}
+	return found
+}
+
+// pinnedVersion returns the only version that the given range includes or nil if the range includes more
+// than one version
+func pinnedVersion(r VersionRange) Version {
+	start, end := r.StartVersion(), r.EndVersion()
+	if start != nil && end != nil && !(r.IsExcludeStart() || r.IsExcludeEnd()) && start.Equals(end) {
+		return start
+	}
+	return nil
+}
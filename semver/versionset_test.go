@@ -0,0 +1,55 @@
+package semver_test
+
+import (
+	"fmt"
+
+	"github.com/lyraproj/semver/semver"
+)
+
+func ExampleVersionSet() {
+	set := semver.NewVersionSet()
+	for _, s := range []string{`1.0.0`, `1.1.0`, `1.2.0`, `1.3.0`} {
+		set.Add(semver.MustParseVersion(s))
+	}
+	fmt.Println(set.SetStatus(semver.MustParseVersion(`1.3.0`), semver.Yanked, `broken build`))
+	fmt.Println(set.SetStatus(semver.MustParseVersion(`1.2.0`), semver.Deprecated, `use 1.1.0`))
+	fmt.Println(set.SetStatus(semver.MustParseVersion(`2.0.0`), semver.Yanked, ``))
+	fmt.Println(set.Status(semver.MustParseVersion(`1.3.0`)))
+	fmt.Println(set.Available())
+
+	r := semver.MustParseVersionRange(`^1.0.0`)
+	fmt.Println(set.MaxSatisfying(r))
+	fmt.Println(set.MaxSatisfying(semver.MustParseVersionRange(`=1.3.0`)))
+	fmt.Println(set.Select(r, semver.MustParseVersion(`1.3.0`)))
+	fmt.Println(set.Select(semver.MustParseVersionRange(`~1.1.0`), semver.MustParseVersion(`1.3.0`)))
+	// Output:
+	// <nil>
+	// <nil>
+	// version 2.0.0 is not in the set
+	// yanked broken build
+	// [1.0.0 1.1.0]
+	// 1.1.0
+	// 1.3.0
+	// 1.3.0
+	// 1.1.0
+}
+
+func ExampleVersionSet_MaxSatisfying() {
+	set := semver.NewVersionSet(semver.MustParseVersion(`1.2.3+b`), semver.MustParseVersion(`1.2.4`))
+	set.SetStatus(semver.MustParseVersion(`1.2.3+b`), semver.Yanked, `broken build`)
+	fmt.Println(set.MaxSatisfying(semver.MustParseVersionRange(`=1.2.3`)))
+	fmt.Println(set.MaxSatisfying(semver.MustParseVersionRange(`~1.2.3`)))
+	fmt.Println(set.Select(semver.MustParseVersionRange(`~1.2.3`), semver.MustParseVersion(`1.2.3`)))
+	// Output:
+	// 1.2.3+b
+	// 1.2.4
+	// 1.2.3+b
+}
+
+func ExampleParseStatus() {
+	fmt.Println(semver.ParseStatus(`Deprecated`))
+	fmt.Println(semver.ParseStatus(`gone`))
+	// Output:
+	// deprecated <nil>
+	// active 'gone' is not a valid version status
+}